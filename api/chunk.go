@@ -0,0 +1,140 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// 分块请求（如 device.APIv3.GetDeviceStatusAll 等 "All" 系列方法）默认的最大并发数。
+const DefaultChunkConcurrency = 4
+
+// 分块请求的配置选项。
+type ChunkOption func(*chunkOptions)
+
+type chunkOptions struct {
+	concurrency int
+}
+
+// 设置分块请求的最大并发数，默认为 DefaultChunkConcurrency，小于 1 时按 1 处理。
+func WithChunkConcurrency(n int) ChunkOption {
+	return func(o *chunkOptions) {
+		o.concurrency = max(n, 1)
+	}
+}
+
+// ChunkError 是分块请求中单个分块的失败。
+type ChunkError struct {
+	Index int   // 分块序号，从 0 开始
+	Start int   // 分块在原始输入中的起始位置（包含）
+	End   int   // 分块在原始输入中的结束位置（不包含）
+	Err   error // 请求错误，或 API 返回的业务错误（如 *APIError）
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk %d [%d:%d]: %v", e.Index, e.Start, e.End, e.Err)
+}
+
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// ChunkErrors 是分块请求中所有失败分块的错误，按分块序号排列，其他分块的结果不受影响。
+//   - 可以通过 errors.As 获取，也可以通过 errors.Is/errors.As 判断或获取其中任一分块的错误。
+type ChunkErrors []*ChunkError
+
+func (es ChunkErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%d chunk(s) failed: %s", len(es), strings.Join(msgs, "; "))
+}
+
+func (es ChunkErrors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+	return errs
+}
+
+// 将 items 按每块最多 size 个元素分块，各分块共享 items 的底层数组；size <= 0 时不分块。
+func Chunk[T any](items []T, size int) [][]T {
+	size = chunkSize(len(items), size)
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		chunks = append(chunks, items[start:min(start+size, len(items))])
+	}
+	return chunks
+}
+
+// 规范化分块大小：size <= 0 时整体作为一块，且至少为 1。
+func chunkSize(n, size int) int {
+	if size <= 0 {
+		size = n
+	}
+	return max(size, 1)
+}
+
+// 将 items 按每块最多 size 个元素分块，以不超过最大并发数（默认为 DefaultChunkConcurrency，可通过 WithChunkConcurrency 设置）对每个分块调用 fn。
+//   - 单个分块的失败不会中断其他分块，所有失败的分块以 ChunkErrors 返回，全部成功时返回 nil；
+//   - ctx 被取消后，尚未开始的分块以 ctx 的错误失败；
+//   - fn 可能被并发调用，按分块序号 index 保存结果即可避免加锁。
+func ForEachChunk[T any](ctx context.Context, items []T, size int, fn func(ctx context.Context, index int, chunk []T) error, opts ...ChunkOption) error {
+	o := chunkOptions{concurrency: DefaultChunkConcurrency}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	size = chunkSize(len(items), size)
+	chunks := Chunk(items, size)
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, o.concurrency)
+
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(ctx, i, chunk)
+		}()
+	}
+	wg.Wait()
+
+	var result ChunkErrors
+	for i, err := range errs {
+		if err != nil {
+			start := i * size
+			result = append(result, &ChunkError{Index: i, Start: start, End: start + len(chunks[i]), Err: err})
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+func TestChunk(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	chunks := api.Chunk(items, 3)
+	if len(chunks) != 3 || len(chunks[0]) != 3 || len(chunks[2]) != 1 || chunks[2][0] != 7 {
+		t.Fatalf("unexpected chunks: %v", chunks)
+	}
+	if chunks := api.Chunk([]int(nil), 3); len(chunks) != 0 {
+		t.Fatalf("expected no chunks for empty input, got %v", chunks)
+	}
+	if chunks := api.Chunk(items, 10); len(chunks) != 1 || len(chunks[0]) != 7 {
+		t.Fatalf("expected a single chunk, got %v", chunks)
+	}
+	for _, size := range []int{0, -1} {
+		if chunks := api.Chunk(items, size); len(chunks) != 1 || len(chunks[0]) != 7 {
+			t.Fatalf("size %d: expected a single chunk, got %v", size, chunks)
+		}
+		if chunks := api.Chunk([]int(nil), size); len(chunks) != 0 {
+			t.Fatalf("size %d: expected no chunks for empty input, got %v", size, chunks)
+		}
+	}
+}
+
+func TestForEachChunk_EmptyAndUnsized(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		var calls int32
+		err := api.ForEachChunk(context.Background(), nil, size, func(context.Context, int, []int) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})
+		if err != nil || calls != 0 {
+			t.Fatalf("size %d: expected no calls for empty input, got %d calls, err %v", size, calls, err)
+		}
+
+		errBoom := errors.New("boom")
+		err = api.ForEachChunk(context.Background(), make([]int, 5), size, func(_ context.Context, _ int, chunk []int) error {
+			return errBoom
+		})
+		var chunkErrs api.ChunkErrors
+		if !errors.As(err, &chunkErrs) || len(chunkErrs) != 1 {
+			t.Fatalf("size %d: expected one chunk error, got %v", size, err)
+		}
+		if e := chunkErrs[0]; e.Index != 0 || e.Start != 0 || e.End != 5 {
+			t.Fatalf("size %d: unexpected chunk error: %+v", size, e)
+		}
+	}
+}
+
+func TestForEachChunk_PartialFailure(t *testing.T) {
+	errBoom := errors.New("boom")
+	items := make([]int, 25)
+
+	var seen int32
+	err := api.ForEachChunk(context.Background(), items, 10, func(_ context.Context, index int, chunk []int) error {
+		atomic.AddInt32(&seen, int32(len(chunk)))
+		if index == 1 {
+			return errBoom
+		}
+		return nil
+	})
+	if seen != 25 {
+		t.Fatalf("expected all chunks to run, got %d items", seen)
+	}
+
+	var chunkErrs api.ChunkErrors
+	if !errors.As(err, &chunkErrs) || len(chunkErrs) != 1 {
+		t.Fatalf("expected one chunk error, got %v", err)
+	}
+	if e := chunkErrs[0]; e.Index != 1 || e.Start != 10 || e.End != 20 {
+		t.Fatalf("unexpected chunk error: %+v", e)
+	}
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected errors.Is to reach the chunk error, got %v", err)
+	}
+}
+
+func TestForEachChunk_Concurrency(t *testing.T) {
+	var running, peak int32
+	err := api.ForEachChunk(context.Background(), make([]int, 10), 1, func(context.Context, int, []int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}, api.WithChunkConcurrency(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent chunks, got %d", peak)
+	}
+}
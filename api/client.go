@@ -17,6 +17,8 @@ package api
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -44,19 +46,31 @@ type HttpClient interface {
 	FormRequest(ctx context.Context, req *Request) (resp *Response, err error)
 }
 
+// ProtoContextDetector 是 HttpClient 可选实现的接口，用于探测可被 `ctx` 取消的 HTTP 协议版本。
+//   - NewHttpClient 返回的 HttpClient 实现了该接口，可通过类型断言使用。
+type ProtoContextDetector interface {
+	DetectProtoContext(ctx context.Context, url string) string
+}
+
 // ---------------------------------------------------------------------------------------------------------------------
 
 // HttpClient 接口的内部默认实现，可记录下 HTTP 请求和响应的日志信息。
 type loggingHttpClient struct {
-	client     Client
-	httpLogger *httpLogger
+	client      Client
+	httpLogger  *httpLogger
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	middlewares []Middleware
+	strict      bool
+	probeCtx    context.Context // 后台探测 HTTP 协议版本时使用的上下文
+	protoOwner  interface{}     // 协议版本缓存的归属，详见 protoOwner
 }
 
 // 在 API 没有提供自定义 Client 时使用 DefaultClient。
 var DefaultClient Client = &http.Client{Timeout: 30 * time.Second}
 
-func NewHttpClient(client Client, logger jiguang.Logger, level HttpLogLevel) HttpClient {
-	lc := loggingHttpClient{}
+func NewHttpClient(client Client, logger jiguang.Logger, level HttpLogLevel, opts ...HttpClientOption) HttpClient {
+	lc := loggingHttpClient{probeCtx: context.Background()}
 	if client == nil {
 		lc.client = DefaultClient
 	} else {
@@ -69,20 +83,93 @@ func NewHttpClient(client Client, logger jiguang.Logger, level HttpLogLevel) Htt
 		level = HttpLogLevelNone
 	}
 	lc.httpLogger = newHttpLogger(logger, level)
+	lc.protoOwner = protoOwner(&lc)
+	for _, opt := range opts {
+		opt(&lc)
+	}
 	return &lc
 }
 
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 用于配置 HttpClient 的选项。
+type HttpClientOption func(*loggingHttpClient)
+
+// 自定义设置 HttpClient 的重试策略，默认为 nil，即不重试。
+func WithRetryPolicy(policy *RetryPolicy) HttpClientOption {
+	return func(lc *loggingHttpClient) {
+		lc.retryPolicy = policy
+	}
+}
+
+// 自定义设置 HttpClient 的客户端频率限制器，默认为 nil，即不在客户端进行频率控制。
+func WithRateLimiter(limiter *RateLimiter) HttpClientOption {
+	return func(lc *loggingHttpClient) {
+		lc.rateLimiter = limiter
+	}
+}
+
+// 自定义设置 HttpClient 的请求/响应中间件，按照设置的顺序由外向内依次执行，详见 Middleware 说明。
+func WithMiddlewares(middlewares ...Middleware) HttpClientOption {
+	return func(lc *loggingHttpClient) {
+		lc.middlewares = append(lc.middlewares, middlewares...)
+	}
+}
+
+// 自定义设置 HttpClient 是否启用严格错误模式，启用后 API 调用失败时（响应状态码不是 2xx 或错误码不为 0）直接返回 *APIError 错误，默认为 false。
+func WithStrictErrors(strict bool) HttpClientOption {
+	return func(lc *loggingHttpClient) {
+		lc.strict = strict
+	}
+}
+
+// 自定义设置 HttpClient 在后台探测 HTTP 协议版本时使用的上下文，取消该上下文即可中止尚未完成的探测请求，默认为 context.Background()。
+//   - 每个探测请求的耗时仍不超过 DetectProtoTimeout。
+func WithProbeContext(ctx context.Context) HttpClientOption {
+	return func(lc *loggingHttpClient) {
+		if ctx != nil {
+			lc.probeCtx = ctx
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
 // 探测给定 URL 对应服务器支持的 HTTP 协议版本，如 "HTTP/1.0"、"HTTP/1.1"、"HTTP/2.0" 等。
+//
+// 探测结果按 Host 缓存在进程内，被使用同一 Client 的所有 HttpClient 共享；同一 Host 正在探测中时等待该探测结束。
+// 探测请求的耗时不超过 DetectProtoTimeout，探测失败时返回 "HTTP/1.1"。
 func (lc *loggingHttpClient) DetectProto(url string) string {
-	req, err := http.NewRequest(http.MethodHead, url, nil)
-	if err != nil {
-		return "HTTP/1.1"
+	return lc.DetectProtoContext(context.Background(), url)
+}
+
+// 同 DetectProto，探测请求（或等待其他探测结束）可通过 `ctx` 取消，被取消的探测不会被记为探测失败。
+func (lc *loggingHttpClient) DetectProtoContext(ctx context.Context, url string) string {
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	resp, err := lc.client.Do(req)
-	if err != nil {
-		return "HTTP/1.1"
+	key := protoCacheKey{owner: lc.protoOwner, host: protoKey(url)}
+	for {
+		proto, done, started := protos.begin(key)
+		switch {
+		case proto != "":
+			return proto
+		case started:
+			if proto = lc.probe(ctx, key); proto != "" {
+				return proto
+			}
+			return defaultProto
+		case done == nil: // 最近探测失败过
+			return defaultProto
+		}
+
+		// 等待其他调用方的探测结束后重新读取缓存；如果该探测被放弃，则由当前调用方重新发起。
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return defaultProto
+		}
 	}
-	return resp.Proto
 }
 
 // 使用 JSON 正文 `Content-Type: application/json;charset=UTF-8` 发送 HTTP 请求。
@@ -91,12 +178,7 @@ func (lc *loggingHttpClient) Request(ctx context.Context, req *Request) (resp *R
 		ctx = context.Background()
 	}
 
-	applicationJSONRequest, err := newApplicationJSONRequest(ctx, req)
-	if err != nil {
-		return
-	}
-
-	return lc.doRequest(ctx, applicationJSONRequest)
+	return lc.send(ctx, req, lc.sendJSON)
 }
 
 // 使用多部分表单数据正文 `Content-Type: multipart/form-data; boundary=...` 发送 HTTP 请求。
@@ -105,16 +187,123 @@ func (lc *loggingHttpClient) FormRequest(ctx context.Context, req *Request) (res
 		ctx = context.Background()
 	}
 
+	return lc.send(ctx, req, lc.sendForm)
+}
+
+// 获取请求授权信息后，经过中间件链发送请求并检查响应结果。
+//   - 如果设置了 Request.Authorizer，则每次发送请求时都会重新获取授权信息，并在服务端返回 401 时使其缓存的凭证失效。
+func (lc *loggingHttpClient) send(ctx context.Context, req *Request, handler Handler) (*Response, error) {
+	if req.Authorizer != nil {
+		auth, err := req.Authorizer.Authorization(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get authorization: %w", err)
+		}
+		req.Auth = auth
+	}
+
+	resp, err := chainMiddlewares(handler, lc.middlewares)(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.New("middleware returned a nil *Response")
+	}
+	if resp.StatusCode == http.StatusUnauthorized && req.Authorizer != nil {
+		invalidate(req.Authorizer)
+	}
+	return lc.checkResponse(req, resp)
+}
+
+// 检查 API 调用的响应结果，必要时将其转换为 *APIError 错误：
+//   - 响应状态码不是 2xx，并且响应正文无法被解析为 JSON 时，总是返回 *APIError 错误；
+//   - 启用了严格错误模式时，响应状态码不是 2xx 或者响应正文中的错误码不为 0，也返回 *APIError 错误。
+func (lc *loggingHttpClient) checkResponse(req *Request, resp *Response) (*Response, error) {
+	success := resp.StatusCode/100 == 2
+	codeErr, ok := parseCodeError(resp.RawBody)
+	if !success && !ok {
+		return nil, newAPIError(req, resp, nil)
+	}
+	if lc.strict && (!success || !codeErr.IsSuccess()) {
+		return nil, newAPIError(req, resp, codeErr)
+	}
+	return resp, nil
+}
+
+// 中间件链末端的 Handler，创建并发送带有 JSON 正文负载的 HTTP 请求。
+func (lc *loggingHttpClient) sendJSON(ctx context.Context, req *Request) (*Response, error) {
+	applicationJSONRequest, err := newApplicationJSONRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Proto == "" {
+		applicationJSONRequest.Proto = lc.lazyProto(req.URL)
+	}
+
+	return lc.doRequest(ctx, applicationJSONRequest, req.isIdempotent())
+}
+
+// 中间件链末端的 Handler，创建并发送带有多部分表单数据正文负载的 HTTP 请求。
+func (lc *loggingHttpClient) sendForm(ctx context.Context, req *Request) (*Response, error) {
 	multipartFormDataRequest, err := newMultipartFormDataRequest(ctx, req)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	return lc.doRequest(ctx, multipartFormDataRequest)
+	if req.Proto == "" {
+		multipartFormDataRequest.Proto = lc.lazyProto(req.URL)
+	}
+
+	return lc.doRequest(ctx, multipartFormDataRequest, req.isIdempotent())
+}
+
+// 执行 HTTP 请求，并按照重试策略在遇到暂时性错误时自动重试。
+func (lc *loggingHttpClient) doRequest(ctx context.Context, httpReq *http.Request, idempotent bool) (resp *Response, err error) {
+	for attempt := 1; ; attempt++ {
+		limited, limitErr := lc.rateLimiter.wait(ctx, httpReq)
+		if limited > 0 && lc.httpLogger.Level >= HttpLogLevelBasic {
+			lc.httpLogger.Debugf(ctx, "---- rate limited, waited %v before %s %s", limited, httpReq.Method, httpReq.URL)
+		}
+		if limitErr != nil {
+			if httpReq.Body != nil {
+				_ = httpReq.Body.Close() // 释放尚未发送的正文，如流式上传的文件
+			}
+			return nil, limitErr
+		}
+
+		resp, err = lc.roundTrip(ctx, httpReq)
+		if resp != nil {
+			resp.Attempts = attempt
+		}
+		lc.rateLimiter.update(httpReq, resp)
+
+		wait, retry := lc.retryPolicy.next(attempt, idempotent, resp, err)
+		if !retry || httpReq.GetBody == nil {
+			return
+		}
+		if lc.httpLogger.Level >= HttpLogLevelBasic {
+			if err != nil {
+				lc.httpLogger.Debugf(ctx, "<-x- %v, retrying in %v (attempt %d)", err, wait, attempt+1)
+			} else {
+				lc.httpLogger.Debugf(ctx, "<-x- %d %s, retrying in %v (attempt %d)", resp.StatusCode, http.StatusText(resp.StatusCode), wait, attempt+1)
+			}
+		}
+		if err = sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		// 重新获取请求正文，以便再次发送。
+		body, bodyErr := httpReq.GetBody()
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+		httpReq = httpReq.Clone(ctx)
+		httpReq.Body = body
+	}
 }
 
-// 执行 HTTP 请求，并根据适当的日志记录级别记录下请求和响应的日志信息。
-func (lc *loggingHttpClient) doRequest(ctx context.Context, httpReq *http.Request) (resp *Response, err error) {
+// 执行单次 HTTP 请求，并根据适当的日志记录级别记录下请求和响应的日志信息。
+func (lc *loggingHttpClient) roundTrip(ctx context.Context, httpReq *http.Request) (resp *Response, err error) {
 	startTime := time.Now()
 	lc.logRequest(ctx, httpReq)
 
@@ -132,7 +321,12 @@ func (lc *loggingHttpClient) doRequest(ctx context.Context, httpReq *http.Reques
 
 	lc.logResponse(ctx, httpResp, startTime)
 
-	defer func() { err = httpResp.Body.Close() }()
+	defer func() {
+		// 不要用关闭正文的结果覆盖读取正文时的错误。
+		if closeErr := httpResp.Body.Close(); err == nil {
+			err = closeErr
+		}
+	}()
 
 	rawBody, err := io.ReadAll(httpResp.Body)
 	if err != nil {
@@ -172,6 +366,13 @@ func (lc *loggingHttpClient) logRequest(ctx context.Context, req *http.Request)
 	if lc.httpLogger.Level == HttpLogLevelFull {
 		if req.Body == nil {
 			lc.httpLogger.Debug(ctx, "<no content>")
+		} else if strings.Contains(req.Header.Get("Content-Type"), "multipart") {
+			// 流式上传的正文不会被读取到内存中，仅记录其大小。
+			if req.ContentLength > 0 {
+				lc.httpLogger.Debugf(ctx, "<streamed multipart> len %d", req.ContentLength)
+			} else {
+				lc.httpLogger.Debug(ctx, "<streamed multipart> len unknown")
+			}
 		} else {
 			body, _ := io.ReadAll(req.Body)
 			req.Body = io.NopCloser(bytes.NewBuffer(body)) // 重置读取后的 body，以便后续读取
@@ -0,0 +1,281 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Credentials 是访问极光 REST API 或校验回调请求所使用的凭证。
+type Credentials struct {
+	Key    string `json:"key"`    // 标识，如 appKey、devKey、groupKey、channelKey 等。
+	Secret string `json:"secret"` // 密钥，如 masterSecret、devSecret、groupMasterSecret 等。
+}
+
+// 检查凭证是否完整。
+func (c Credentials) validate() error {
+	if c.Key == "" || c.Secret == "" {
+		return errors.New("both credentials `key` and `secret` cannot be empty")
+	}
+	return nil
+}
+
+// CredentialsProvider 是凭证的提供者，每次发送请求（或校验回调请求）时都会被调用，以支持密钥的热轮换。
+//   - 实现必须是并发安全的，并且应当足够快，必要时可使用 CachedCredentials 包装以缓存结果；
+//   - 如果实现了 Invalidator 接口，则在服务端返回 401 鉴权失败时调用其 Invalidate 方法，以便在下次请求时重新获取凭证。
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// Invalidator 用于使已缓存的凭证失效。
+type Invalidator interface {
+	Invalidate()
+}
+
+// 如果 CredentialsProvider 实现了 Invalidator 接口，则使其缓存的凭证失效。
+func invalidate(provider interface{}) {
+	if inv, ok := provider.(Invalidator); ok {
+		inv.Invalidate()
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+type staticCredentials Credentials
+
+// 静态凭证，总是返回给定的 key 和 secret。
+func StaticCredentials(key, secret string) CredentialsProvider {
+	return staticCredentials{Key: key, Secret: secret}
+}
+
+func (c staticCredentials) Credentials(_ context.Context) (Credentials, error) {
+	return Credentials(c), nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+type envCredentials struct {
+	keyEnv, secretEnv string
+}
+
+// 从环境变量中读取凭证，每次调用时都会重新读取，因此修改环境变量后立即生效。
+func EnvCredentials(keyEnv, secretEnv string) CredentialsProvider {
+	return envCredentials{keyEnv: keyEnv, secretEnv: secretEnv}
+}
+
+func (c envCredentials) Credentials(_ context.Context) (Credentials, error) {
+	creds := Credentials{Key: os.Getenv(c.keyEnv), Secret: os.Getenv(c.secretEnv)}
+	if err := creds.validate(); err != nil {
+		return Credentials{}, fmt.Errorf("env `%s`/`%s`: %w", c.keyEnv, c.secretEnv, err)
+	}
+	return creds, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// FileCredentialsProvider 从 JSON 文件中读取凭证，并在文件被修改后自动重新加载。
+//
+// 文件内容的格式为 `{"key": "...", "secret": "..."}`；如果重新加载失败（如文件正在被写入），则继续使用上一次成功加载的凭证。
+//
+// 注意：这里不监听文件系统事件，而是在调用 Credentials 时轮询文件的修改时间和大小，且最多每隔 CheckInterval 检查一次，
+// 因此文件被修改后，新的凭证会在距上一次检查超过 CheckInterval 后的下一次请求中生效；没有请求时不会读取文件。
+type FileCredentialsProvider struct {
+	path          string
+	checkInterval time.Duration
+
+	mu        sync.Mutex
+	creds     Credentials
+	loaded    bool
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+}
+
+// 创建从 JSON 文件中读取凭证的提供者，checkInterval 为检查文件是否被修改的最小间隔，不大于 0 时默认为 10 秒。
+func FileCredentials(path string, checkInterval time.Duration) *FileCredentialsProvider {
+	if checkInterval <= 0 {
+		checkInterval = 10 * time.Second
+	}
+	return &FileCredentialsProvider{path: path, checkInterval: checkInterval}
+}
+
+func (p *FileCredentialsProvider) Credentials(_ context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.loaded && now.Sub(p.checkedAt) < p.checkInterval {
+		return p.creds, nil
+	}
+	p.checkedAt = now
+
+	fi, err := os.Stat(p.path)
+	if err != nil {
+		return p.fallback(err)
+	}
+	if p.loaded && fi.ModTime().Equal(p.modTime) && fi.Size() == p.size {
+		return p.creds, nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return p.fallback(err)
+	}
+	var creds Credentials
+	if err = json.Unmarshal(data, &creds); err != nil {
+		return p.fallback(fmt.Errorf("parse credentials file %q: %w", p.path, err))
+	}
+	if err = creds.validate(); err != nil {
+		return p.fallback(fmt.Errorf("credentials file %q: %w", p.path, err))
+	}
+
+	p.creds, p.loaded = creds, true
+	p.modTime, p.size = fi.ModTime(), fi.Size()
+	return creds, nil
+}
+
+// 加载失败时，如果已经成功加载过，则继续使用上一次的凭证，否则返回错误。
+func (p *FileCredentialsProvider) fallback(err error) (Credentials, error) {
+	if p.loaded {
+		return p.creds, nil
+	}
+	return Credentials{}, err
+}
+
+// 使下次调用 Credentials 时立即检查文件是否被修改。
+func (p *FileCredentialsProvider) Invalidate() {
+	p.mu.Lock()
+	p.checkedAt = time.Time{}
+	p.mu.Unlock()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// CachedCredentialsProvider 缓存另一个 CredentialsProvider 返回的凭证，适用于从远程密钥管理服务获取凭证等较慢的场景。
+//   - 缓存过期后，并发的调用只会触发一次获取，获取过程中不持有锁，调用方可通过各自的上下文放弃等待；
+//   - 获取在后台进行，不受发起调用的上下文取消的影响，以免一个调用方放弃等待导致其它调用方失败。
+type CachedCredentialsProvider struct {
+	provider CredentialsProvider
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	creds     Credentials
+	expiresAt time.Time
+	fetch     *credentialsFetch // 正在进行中的获取，nil 表示没有
+	gen       uint64            // 每次 Invalidate 时递增，失效前发起的获取结果不再写入缓存
+}
+
+// 一次正在进行中的凭证获取，done 关闭后 creds 和 err 可读。
+type credentialsFetch struct {
+	done  chan struct{}
+	creds Credentials
+	err   error
+}
+
+// 创建缓存凭证的提供者，缓存的凭证在 ttl 后过期，或在调用 Invalidate 后立即失效。
+func CachedCredentials(provider CredentialsProvider, ttl time.Duration) *CachedCredentialsProvider {
+	return &CachedCredentialsProvider{provider: provider, ttl: ttl}
+}
+
+func (p *CachedCredentialsProvider) Credentials(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	if time.Now().Before(p.expiresAt) {
+		creds := p.creds
+		p.mu.Unlock()
+		return creds, nil
+	}
+	f := p.fetch
+	if f == nil {
+		f = &credentialsFetch{done: make(chan struct{})}
+		p.fetch = f
+		go p.refresh(context.WithoutCancel(ctx), f, p.gen)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.creds, f.err
+	case <-ctx.Done():
+		return Credentials{}, ctx.Err()
+	}
+}
+
+// 从被包装的提供者获取凭证，并在缓存未被失效时写入缓存。
+func (p *CachedCredentialsProvider) refresh(ctx context.Context, f *credentialsFetch, gen uint64) {
+	creds, err := p.provider.Credentials(ctx)
+
+	p.mu.Lock()
+	if err == nil && gen == p.gen {
+		p.creds, p.expiresAt = creds, time.Now().Add(p.ttl)
+	}
+	if p.fetch == f {
+		p.fetch = nil
+	}
+	p.mu.Unlock()
+
+	f.creds, f.err = creds, err
+	close(f.done)
+}
+
+// 使缓存的凭证立即失效，同时使被包装的提供者的缓存失效（如果它实现了 Invalidator 接口）。
+//   - 不会等待正在进行中的获取，之后的调用会重新发起获取。
+func (p *CachedCredentialsProvider) Invalidate() {
+	p.mu.Lock()
+	p.expiresAt = time.Time{}
+	p.fetch = nil
+	p.gen++
+	p.mu.Unlock()
+	invalidate(p.provider)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Authorizer 用于在发送每个请求时生成请求授权信息，即 `Authorization` 请求头的值。
+type Authorizer interface {
+	Authorization(ctx context.Context) (string, error)
+}
+
+type basicAuth struct {
+	provider  CredentialsProvider
+	keyPrefix string
+}
+
+// 基于给定凭证提供者的 HTTP Basic 授权，即 "Basic " + base64(keyPrefix + key + ":" + secret)。
+//   - keyPrefix 为标识的前缀，如分组推送的 "group-"，通常为空。
+func BasicAuth(provider CredentialsProvider, keyPrefix string) Authorizer {
+	return &basicAuth{provider: provider, keyPrefix: keyPrefix}
+}
+
+func (a *basicAuth) Authorization(ctx context.Context) (string, error) {
+	creds, err := a.provider.Credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err = creds.validate(); err != nil {
+		return "", err
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(a.keyPrefix+creds.Key+":"+creds.Secret)), nil
+}
+
+func (a *basicAuth) Invalidate() {
+	invalidate(a.provider)
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+func basic(key, secret string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(key+":"+secret))
+}
+
+func TestFileCredentialsRotation(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "creds.json")
+	write := func(content string, modTime time.Time) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	write(`{"key":"app","secret":"old"}`, now.Add(-time.Minute))
+
+	provider := api.FileCredentials(path, time.Nanosecond)
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+	send := func() {
+		req := &api.Request{Method: http.MethodGet, Proto: "HTTP/1.1", URL: srv.URL, Authorizer: api.BasicAuth(provider, "group-")}
+		if _, err := client.Request(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	send()
+	write(`{"key":"app","secret":"new"}`, now)
+	send()
+	write(`{"key":"app"`, now.Add(time.Minute)) // 写入中途的文件，继续使用上一次的凭证
+	send()
+
+	want := []string{basic("group-app", "old"), basic("group-app", "new"), basic("group-app", "new")}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d: Authorization = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+// 每次调用时返回下一个密钥的凭证提供者。
+type rotatingCredentials struct {
+	secrets []string
+	calls   int
+}
+
+func (p *rotatingCredentials) Credentials(_ context.Context) (api.Credentials, error) {
+	secret := p.secrets[p.calls%len(p.secrets)]
+	p.calls++
+	return api.Credentials{Key: "app", Secret: secret}, nil
+}
+
+func TestCachedCredentialsInvalidatedOnUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != basic("app", "new") {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	source := &rotatingCredentials{secrets: []string{"old", "new"}}
+	auth := api.BasicAuth(api.CachedCredentials(source, time.Hour), "")
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+
+	statuses := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		resp, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, Proto: "HTTP/1.1", URL: srv.URL, Authorizer: auth})
+		if err != nil {
+			t.Fatal(err)
+		}
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if statuses[0] != http.StatusUnauthorized || statuses[1] != http.StatusOK || statuses[2] != http.StatusOK {
+		t.Errorf("statuses = %v, want [401 200 200]", statuses)
+	}
+	if source.calls != 2 {
+		t.Errorf("source calls = %d, want 2", source.calls)
+	}
+}
+
+// 较慢的凭证提供者，每次获取都会等待 release 被关闭。
+type slowCredentials struct {
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (p *slowCredentials) Credentials(_ context.Context) (api.Credentials, error) {
+	p.calls.Add(1)
+	<-p.release
+	return api.Credentials{Key: "app", Secret: "secret"}, nil
+}
+
+func TestCachedCredentialsSingleFetch(t *testing.T) {
+	source := &slowCredentials{release: make(chan struct{})}
+	cached := api.CachedCredentials(source, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if creds, err := cached.Credentials(context.Background()); err != nil || creds.Secret != "secret" {
+				t.Errorf("Credentials() = %+v, %v", creds, err)
+			}
+		}()
+	}
+
+	// 获取过程中，Invalidate 和放弃等待的调用方都不会被阻塞。
+	for source.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	invalidated := make(chan struct{})
+	go func() {
+		cached.Invalidate()
+		close(invalidated)
+	}()
+	select {
+	case <-invalidated:
+	case <-time.After(time.Second):
+		t.Fatal("Invalidate() blocked by an in-flight fetch")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := cached.Credentials(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Credentials() err = %v, want context.DeadlineExceeded", err)
+	}
+
+	close(source.release)
+	wg.Wait()
+	if n := source.calls.Load(); n != 2 {
+		t.Errorf("source calls = %d, want 2 (one before and one after Invalidate)", n)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("TEST_JG_KEY", "k")
+	t.Setenv("TEST_JG_SECRET", "")
+	provider := api.EnvCredentials("TEST_JG_KEY", "TEST_JG_SECRET")
+	if _, err := provider.Credentials(context.Background()); err == nil {
+		t.Error("want error for empty secret")
+	}
+	t.Setenv("TEST_JG_SECRET", "s")
+	if creds, err := provider.Credentials(context.Background()); err != nil || creds != (api.Credentials{Key: "k", Secret: "s"}) {
+		t.Errorf("creds = %+v, err = %v", creds, err)
+	}
+}
@@ -14,7 +14,14 @@
 
 package api
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
 
 // API 访问客户端未初始化错误哨兵。
 
@@ -96,3 +103,196 @@ func (e *CodeError) String() string {
 func (e *CodeError) IsSuccess() bool {
 	return e == nil || e.Code == 0
 }
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 常见 API 调用失败原因的错误哨兵，可通过 errors.Is 判断 *APIError 是否属于某一类错误。
+
+var (
+	ErrAuthFailed  = errors.New("authentication failed") // 鉴权失败
+	ErrRateLimited = errors.New("rate limited")          // 请求频率超限
+	// 推送目标不合法。
+	//  - JPush/JUMS 没有推送目标专用的错误码，仅当错误码为 1003（参数值不合法）并且错误描述中包含 "audience" 时才归为此类，
+	//  如 `{"error":{"code":1003,"message":"audience value must be JSON Array format!"}}`；
+	//  - 该判断依赖服务端返回的英文错误描述，其他参数值不合法的 1003 错误不会被归为此类。
+	ErrInvalidAudience     = errors.New("invalid audience")
+	ErrNoTargetDevices     = errors.New("no target devices")        // 没有满足条件的推送目标
+	ErrInsufficientBalance = errors.New("insufficient sms balance") // 短信余量不足
+	ErrTemplateNotApproved = errors.New("template not approved")    // 模板未通过审核
+)
+
+// 各类错误哨兵对应的极光 REST API 错误码。
+//   - 鉴权失败和请求频率超限还会分别根据 HTTP 状态码 401、429 判断；
+//   - JUMS 沿用 JPush 的错误码，只是以 `{"code": ..., "message": ...}` 格式返回，因此与 JPush 共用下列错误码。
+var sentinelCodes = map[error][]int{
+	// JPush/JUMS：1004 验证失败、1008 AppKey 非法；JSMS：50001 鉴权信息为空、50002 鉴权失败。
+	ErrAuthFailed: {1004, 1008, 50001, 50002},
+	// JPush/JUMS：2002 API 调用频率超出限制、2008 广播推送超出频率限制；JSMS：50009 发送超频。
+	ErrRateLimited: {2002, 2008, 50009},
+	// JPush/JUMS：1011 没有满足条件的推送目标。
+	ErrNoTargetDevices: {1011},
+	// JSMS：50014 可发短信余量不足。
+	ErrInsufficientBalance: {50014},
+	// JSMS：50020 模板审核中或未通过审核，暂不可用。
+	ErrTemplateNotApproved: {50020},
+}
+
+// # API 调用错误
+//
+// 在以下情况下，HttpClient 会返回 *APIError 错误：
+//   - 响应状态码不是 2xx，并且响应正文无法被解析为 JSON（如网关返回的 HTML 错误页面或空正文）；
+//   - 启用了严格错误模式（如 push.APIv3Builder.EnableStrictErrors），并且响应状态码不是 2xx 或者响应正文中的错误码不为 0。
+//
+// 可以通过 errors.As 获取错误详情，或者通过 errors.Is 判断错误类别，如 errors.Is(err, api.ErrRateLimited)。
+type APIError struct {
+	StatusCode int        // HTTP 状态码
+	CodeError  *CodeError // 极光 REST API 返回的错误码及错误描述，无法解析时为 nil
+	Endpoint   string     // 请求端点，如 "POST /v3/push"
+	Rate       Rate       // API 频率控制信息
+	RawBody    []byte     // 原始响应正文
+}
+
+// 根据请求和响应创建 API 调用错误。
+func newAPIError(req *Request, resp *Response, codeErr *CodeError) *APIError {
+	endpoint := req.URL
+	if u, err := url.Parse(req.URL); err == nil {
+		endpoint = "/" + strings.TrimPrefix(u.Path, "/")
+	}
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		CodeError:  codeErr,
+		Endpoint:   req.Method + " " + endpoint,
+		Rate:       resp.Rate,
+		RawBody:    resp.RawBody,
+	}
+}
+
+// 根据请求端点（如 "POST /v3/push"）和不成功的响应创建 API 调用错误，用于分块请求等需要将业务错误统一为 error 的场景。
+func NewAPIError(endpoint string, resp *Response, codeErr *CodeError) *APIError {
+	e := &APIError{CodeError: codeErr, Endpoint: endpoint}
+	if resp != nil {
+		e.StatusCode, e.Rate, e.RawBody = resp.StatusCode, resp.Rate, resp.RawBody
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e == nil {
+		return "nil api error"
+	}
+	msg := fmt.Sprintf("%s: %d %s", e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	if !e.CodeError.IsSuccess() {
+		msg += ": " + e.CodeError.Error()
+	}
+	return msg
+}
+
+// 判断错误是否属于 `target` 错误哨兵所表示的类别。
+func (e *APIError) Is(target error) bool {
+	if e == nil {
+		return false
+	}
+	switch {
+	case target == ErrAuthFailed && e.StatusCode == http.StatusUnauthorized:
+		return true
+	case target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests:
+		return true
+	case target == ErrInvalidAudience && e.CodeError != nil && e.CodeError.Code == 1003:
+		// JPush 的 1003 表示参数值不合法，仅当错误描述指向推送目标时才归为此类。
+		return strings.Contains(strings.ToLower(e.CodeError.Message), "audience")
+	}
+	if e.CodeError == nil {
+		return false
+	}
+	for _, code := range sentinelCodes[target] {
+		if e.CodeError.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// 从响应正文中解析错误码，兼容 `{"error": {"code": ..., "message": ...}}` 和 `{"code": ..., "message": ...}` 两种格式。
+//
+// 如果响应正文不是合法的 JSON，则 ok 为 false。
+func parseCodeError(body []byte) (codeErr *CodeError, ok bool) {
+	if !json.Valid(body) {
+		return nil, false
+	}
+	var envelope struct {
+		Error   *CodeError `json:"error"`
+		Code    *int       `json:"code"`
+		Message string     `json:"message"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, true // 如 JSON 数组等其它格式的正文
+	}
+	if envelope.Error != nil {
+		return envelope.Error, true
+	}
+	if envelope.Code != nil {
+		return &CodeError{Code: *envelope.Code, Message: envelope.Message}, true
+	}
+	return nil, true
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 请求参数中单个字段的本地校验错误。
+type ValidationError struct {
+	Field   string // 字段路径，与 JSON 字段名一致，如 "options.time_to_live"；为空时表示参数整体的错误
+	Message string // 错误描述
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// 请求参数的所有本地校验错误，按字段的检查顺序排列。
+//   - 可以通过 errors.As 获取全部错误，也可以通过 errors.As 直接获取其中的第一个 *ValidationError。
+type ValidationErrors []*ValidationError
+
+func (es ValidationErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return "invalid param: " + strings.Join(msgs, "; ")
+}
+
+func (es ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+	return errs
+}
+
+// 追加一个字段的校验错误，`format` 和 `args` 用于格式化错误描述。
+func (es *ValidationErrors) Add(field, format string, args ...interface{}) {
+	*es = append(*es, &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// 返回为所有字段路径加上 `prefix.` 前缀的副本，如将 "target" 转换为 "pushlist.cid1.target"，空字段路径则转换为 `prefix`。
+func (es ValidationErrors) WithPrefix(prefix string) ValidationErrors {
+	prefixed := make(ValidationErrors, len(es))
+	for i, e := range es {
+		field := prefix
+		if e.Field != "" {
+			field += "." + e.Field
+		}
+		prefixed[i] = &ValidationError{Field: field, Message: e.Message}
+	}
+	return prefixed
+}
+
+// 没有校验错误时返回 nil，否则返回 es 本身，避免返回带类型的 nil 接口值。
+func (es ValidationErrors) Err() error {
+	if len(es) == 0 {
+		return nil
+	}
+	return es
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		strict   bool
+		wantErr  error
+		wantCode int
+	}{
+		{"non-json error body", http.StatusBadGateway, "<html>bad gateway</html>", false, nil, 0},
+		{"empty error body", http.StatusUnauthorized, "", false, api.ErrAuthFailed, 0},
+		{"jpush auth", http.StatusUnauthorized, `{"error":{"code":1004,"message":"Authen failed"}}`, true, api.ErrAuthFailed, 1004},
+		{"jpush no target", http.StatusBadRequest, `{"error":{"code":1011,"message":"cannot find user by this audience"}}`, true, api.ErrNoTargetDevices, 1011},
+		{"jpush invalid audience", http.StatusBadRequest, `{"error":{"code":1003,"message":"audience value must be JSON Array format!"}}`, true, api.ErrInvalidAudience, 1003},
+		{"jpush rate limited", http.StatusTooManyRequests, `{"error":{"code":2002,"message":"Rate limit exceeded"}}`, true, api.ErrRateLimited, 2002},
+		{"jsms balance", http.StatusForbidden, `{"error":{"code":50014,"message":"no sufficient balance"}}`, true, api.ErrInsufficientBalance, 50014},
+		{"jsms template", http.StatusForbidden, `{"error":{"code":50020,"message":"template not verified"}}`, true, api.ErrTemplateNotApproved, 50020},
+		{"jums code in 2xx", http.StatusOK, `{"code":1001,"message":"failed"}`, true, nil, 1001},
+		{"jums auth", http.StatusOK, `{"code":1004,"message":"Authen failed"}`, true, api.ErrAuthFailed, 1004},
+		{"jums rate limited", http.StatusOK, `{"code":2002,"message":"Rate limit exceeded"}`, true, api.ErrRateLimited, 2002},
+		{"jums no target", http.StatusOK, `{"code":1011,"message":"cannot find user by this audience"}`, true, api.ErrNoTargetDevices, 1011},
+		{"jums invalid audience", http.StatusOK, `{"code":1003,"message":"Audience value is invalid"}`, true, api.ErrInvalidAudience, 1003},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithStrictErrors(tt.strict))
+			_, err := client.Request(context.Background(), &api.Request{Method: http.MethodPost, URL: srv.URL + "/v3/push?x=1"})
+
+			var apiErr *api.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want *api.APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Endpoint != "POST /v3/push" {
+				t.Errorf("status = %d, endpoint = %q", apiErr.StatusCode, apiErr.Endpoint)
+			}
+			if tt.wantCode != 0 && (apiErr.CodeError == nil || apiErr.CodeError.Code != tt.wantCode) {
+				t.Errorf("code error = %v, want %d", apiErr.CodeError, tt.wantCode)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// ErrInvalidAudience 依赖 1003 错误描述中的 "audience"（不区分大小写），此处固定该约定。
+func TestAPIErrorInvalidAudienceHeuristic(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"audience value must be JSON Array format!", true},
+		{"Audience value is invalid", true},
+		{"The value of 'time_to_live' is invalid", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		err := api.NewAPIError("POST /v3/push", &api.Response{StatusCode: http.StatusBadRequest}, &api.CodeError{Code: 1003, Message: tt.message})
+		if got := errors.Is(err, api.ErrInvalidAudience); got != tt.want {
+			t.Errorf("errors.Is(%q, ErrInvalidAudience) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorNotStrict(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":1003,"message":"bad"}}`))
+	}))
+	defer srv.Close()
+
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+	resp, err := client.Request(context.Background(), &api.Request{Method: http.MethodPost, URL: srv.URL})
+	if err != nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("resp = %v, err = %v, want parsed 400 response without error", resp, err)
+	}
+}
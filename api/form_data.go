@@ -15,6 +15,7 @@
 package api
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -34,14 +35,49 @@ type MultipartFormDataBody struct {
 
 // 填充多部分表单数据的正文，包括普通字段和文件。
 func (mfd MultipartFormDataBody) Prepare(writer *multipart.Writer) error {
+	files, err := mfd.open()
+	if err != nil {
+		return err
+	}
+	return mfd.write(writer, files)
+}
+
+// 打开全部文件表单字段，并在上传前完成文件扩展名、MIME 类型和已知文件大小的校验。
+func (mfd MultipartFormDataBody) open() ([]*openedFile, error) {
+	files := make([]*openedFile, 0, len(mfd.Files))
+	for _, ff := range mfd.Files {
+		f, err := ff.open()
+		if err == nil {
+			err = mfd.FileValidator.precheck(f)
+		}
+		if err != nil {
+			if f != nil {
+				f.close()
+			}
+			closeFiles(files)
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// 将普通字段和已打开的文件写入 writer，文件内容在写入过程中被增量校验，写入完毕后关闭通过文件路径打开的文件。
+func (mfd MultipartFormDataBody) write(writer *multipart.Writer, files []*openedFile) error {
+	defer closeFiles(files)
+
 	for _, ff := range mfd.Fields {
 		if err := writer.WriteField(ff.Name, ff.Value); err != nil {
 			return err
 		}
 	}
 
-	for _, ff := range mfd.Files {
-		if err := ff.process(mfd.FileValidator, writer); err != nil {
+	for _, f := range files {
+		fileWriter, err := writer.CreateFormFile(f.fieldName, f.fileName)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(fileWriter, mfd.FileValidator.limit(f)); err != nil {
 			return err
 		}
 	}
@@ -49,6 +85,84 @@ func (mfd MultipartFormDataBody) Prepare(writer *multipart.Writer) error {
 	return nil
 }
 
+// 以流式的方式生成多部分表单数据的正文，而不是将全部文件内容缓冲在内存中；同时返回正文的长度，未知时为 -1。
+func (mfd MultipartFormDataBody) stream(boundary string) (io.ReadCloser, int64, error) {
+	files, err := mfd.open()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	if err = writer.SetBoundary(boundary); err != nil {
+		closeFiles(files)
+		return nil, 0, err
+	}
+	contentLength := mfd.contentLength(boundary, files)
+
+	go func() {
+		err := mfd.write(writer, files)
+		if err == nil {
+			err = writer.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	return pr, contentLength, nil
+}
+
+// 计算多部分表单数据正文的长度，任一文件大小未知时返回 -1。
+func (mfd MultipartFormDataBody) contentLength(boundary string, files []*openedFile) int64 {
+	var size int64
+	for _, f := range files {
+		if f.size < 0 {
+			return -1
+		}
+		size += f.size
+	}
+
+	// 使用相同的分隔符写入除文件内容外的全部数据，以得到表单结构本身的长度。
+	cw := &countingWriter{}
+	writer := multipart.NewWriter(cw)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return -1
+	}
+	for _, ff := range mfd.Fields {
+		if err := writer.WriteField(ff.Name, ff.Value); err != nil {
+			return -1
+		}
+	}
+	for _, f := range files {
+		if _, err := writer.CreateFormFile(f.fieldName, f.fileName); err != nil {
+			return -1
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return -1
+	}
+	return cw.n + size
+}
+
+// 判断正文是否可以被重新生成，即全部文件均通过文件路径指定（数据流只能被读取一次）。
+func (mfd MultipartFormDataBody) reopenable() bool {
+	for _, ff := range mfd.Files {
+		if _, ok := ff.FileData.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// 仅统计写入字节数的 io.Writer。
+type countingWriter struct {
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.n += int64(len(p))
+	return len(p), nil
+}
+
 // ---------------------------------------------------------------------------------------------------------------------
 
 // # 普通表单字段
@@ -64,80 +178,98 @@ type FormFile struct {
 	FileData  interface{} // 文件路径或文件数据流（如果是文件数据流，请在上传完毕后自行关闭）
 }
 
-// 处理文件表单字段，将其内容写入 writer。
-func (ff FormFile) process(fv *FileValidator, writer *multipart.Writer) error {
-	var (
-		fileReader io.Reader
-		fieldName  = ff.FieldName
-		fileName   = ff.FileName
-	)
+// 已打开的待上传文件。
+type openedFile struct {
+	fieldName string
+	fileName  string
+	reader    io.Reader
+	size      int64     // 剩余待上传的文件大小，未知时为 -1
+	closer    io.Closer // 通过文件路径打开的文件，需要在上传完毕后关闭
+}
+
+func (f *openedFile) close() {
+	if f.closer != nil {
+		_ = f.closer.Close()
+	}
+}
+
+func closeFiles(files []*openedFile) {
+	for _, f := range files {
+		f.close()
+	}
+}
+
+// 打开文件表单字段，解析出字段名称、文件名、文件数据流及其大小。
+func (ff FormFile) open() (*openedFile, error) {
+	f := &openedFile{fieldName: ff.FieldName, fileName: ff.FileName, size: -1}
 
 	switch file := ff.FileData.(type) {
 	case string:
 		if file == "" {
-			return fmt.Errorf("%q path is not set", fieldName)
+			return nil, fmt.Errorf("%q path is not set", f.fieldName)
 		}
 		// 处理文件路径，支持 "~" 表示用户目录
 		if strings.HasPrefix(file, "~") {
 			if homeDir, err := os.UserHomeDir(); err != nil {
-				return err
+				return nil, err
 			} else {
 				file = strings.Replace(file, "~", homeDir, 1)
 			}
 		}
-		f, err := os.Open(file)
+		osFile, err := os.Open(file)
 		if err != nil {
-			return err
-		}
-		defer func() { _ = f.Close() }()
-		fileReader = f
-		if fileName == "" {
-			fileName = filepath.Base(file)
+			return nil, err
 		}
-		if fieldName == "" {
-			fieldName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		f.reader, f.closer, f.size = osFile, osFile, fileSize(osFile)
+		if f.fileName == "" {
+			f.fileName = filepath.Base(file)
 		}
 	case *os.File:
 		if file == nil {
-			return fmt.Errorf("nil %q File", fieldName)
-		}
-		fileReader = file
-		if fileName == "" {
-			fileName = filepath.Base(file.Name())
+			return nil, fmt.Errorf("nil %q File", f.fieldName)
 		}
-		if fieldName == "" {
-			fieldName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		f.reader, f.size = file, fileSize(file)
+		if f.fileName == "" {
+			f.fileName = filepath.Base(file.Name())
 		}
 	case io.Reader:
 		if file == nil {
-			return fmt.Errorf("nil %q Reader", fieldName)
+			return nil, fmt.Errorf("nil %q Reader", f.fieldName)
 		}
-		fileReader = file
-		if fileName == "" {
-			fileName = "file" // unexpected, maybe use a random name?
+		f.reader = file
+		if r, ok := file.(interface{ Len() int }); ok {
+			f.size = int64(r.Len())
 		}
-		if fieldName == "" {
-			fieldName = "file" // unexpected, maybe use a random name?
+		if f.fileName == "" {
+			f.fileName = "file" // unexpected, maybe use a random name?
+		}
+		if f.fieldName == "" {
+			f.fieldName = "file" // unexpected, maybe use a random name?
 		}
 	default:
 		if file == nil {
-			return fmt.Errorf("nil %q", fieldName)
+			return nil, fmt.Errorf("nil %q", f.fieldName)
 		}
-		return fmt.Errorf("unsupported file type: %T", file)
+		return nil, fmt.Errorf("unsupported file type: %T", file)
 	}
 
-	if err := fv.Validate(fileReader, fileName); err != nil {
-		return err
+	if f.fieldName == "" {
+		f.fieldName = strings.TrimSuffix(f.fileName, filepath.Ext(f.fileName))
 	}
+	return f, nil
+}
 
-	fileWriter, err := writer.CreateFormFile(fieldName, fileName)
-	if err != nil {
-		return err
+// 获取普通文件从当前读取位置起的剩余大小，无法获取时返回 -1。
+func fileSize(file *os.File) int64 {
+	stat, err := file.Stat()
+	if err != nil || !stat.Mode().IsRegular() {
+		return -1
 	}
-	if _, err = io.Copy(fileWriter, fileReader); err != nil {
-		return err
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return -1
 	}
-	return nil
+	return stat.Size() - offset
 }
 
 // ---------------------------------------------------------------------------------------------------------------------
@@ -242,6 +374,94 @@ func (fv *FileValidator) Validate(fileReader io.Reader, fileName string) error {
 	return nil
 }
 
+// 在上传前校验文件的扩展名、MIME 类型，以及已知的文件大小。
+//
+// 与 Validate 不同，此方法不要求文件数据流支持 Seek：MIME 类型通过预读文件头检测，预读的内容会被保留用于后续上传。
+func (fv *FileValidator) precheck(f *openedFile) error {
+	if fv == nil {
+		return nil
+	}
+
+	// 校验文件扩展名
+	if len(fv.AllowedExts) > 0 {
+		ext := strings.ToLower(filepath.Ext(f.fileName))
+		isAllowed := false
+		for _, allowedExt := range fv.AllowedExts {
+			if ext == allowedExt {
+				isAllowed = true
+				break
+			}
+		}
+		if !isAllowed {
+			return fmt.Errorf("%q ext %q is not allowed", f.fileName, ext)
+		}
+	}
+
+	// 校验已知的文件大小，未知时在上传过程中增量校验
+	if fv.MaxSize > 0 && f.size >= 0 {
+		if f.size == 0 {
+			return fmt.Errorf("%q is empty", f.fileName)
+		}
+		if f.size > fv.MaxSize {
+			return fmt.Errorf("%q size %d exceeds limit of %d bytes", f.fileName, f.size, fv.MaxSize)
+		}
+	}
+
+	// 校验文件 MIME 类型
+	if len(fv.AllowedMimes) > 0 {
+		br := bufio.NewReaderSize(f.reader, 512)
+		buf, err := br.Peek(512) // 预读文件头的前 512 字节用于检测类型
+		if err != nil && err != io.EOF {
+			return err
+		}
+		f.reader = br
+
+		// 检测文件 MIME 类型
+		mimeType := http.DetectContentType(buf)
+		baseMimeType := getBaseMimeType(mimeType)
+		isAllowed := false
+		for _, allowedMime := range fv.AllowedMimes {
+			if mimeType == allowedMime || baseMimeType == allowedMime {
+				isAllowed = true
+				break
+			}
+		}
+		if !isAllowed {
+			return fmt.Errorf("%q MIME %q is not allowed", f.fileName, mimeType)
+		}
+	}
+
+	return nil
+}
+
+// 包装文件数据流，在上传过程中增量校验文件大小。
+func (fv *FileValidator) limit(f *openedFile) io.Reader {
+	if fv == nil || fv.MaxSize <= 0 {
+		return f.reader
+	}
+	return &sizeLimitedReader{reader: f.reader, fileName: f.fileName, maxSize: fv.MaxSize}
+}
+
+// 在读取过程中校验数据流大小的 io.Reader，超过限制或数据流为空时返回错误。
+type sizeLimitedReader struct {
+	reader   io.Reader
+	fileName string
+	maxSize  int64
+	read     int64
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if r.read > r.maxSize {
+		return n, fmt.Errorf("%q size exceeds limit of %d bytes", r.fileName, r.maxSize)
+	}
+	if err == io.EOF && r.read == 0 {
+		return n, fmt.Errorf("%q is empty", r.fileName)
+	}
+	return n, err
+}
+
 // MIME 类型正则表达式
 var mimeRegexp = regexp.MustCompile(`^(.*?)(;.*)?$`)
 
@@ -0,0 +1,120 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// 解析多部分表单数据请求，返回 Content-Length 请求头和各文件字段的内容。
+func newMultipartServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"code":1,"message":"` + err.Error() + `"}}`))
+			return
+		}
+		f, fh, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(f)
+		_, _ = w.Write([]byte(`{"len":` + strconv.FormatInt(r.ContentLength, 10) + `,"name":"` + fh.Filename + `","data":"` + string(data) + `","type":"` + r.FormValue("type") + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFormRequestStreaming(t *testing.T) {
+	srv := newMultipartServer(t)
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+
+	path := filepath.Join(t.TempDir(), "rids.txt")
+	if err := os.WriteFile(path, []byte("rid1\nrid2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		fileData  interface{}
+		wantName  string
+		knownSize bool
+	}{
+		{"file path", path, "rids.txt", true},
+		{"sized reader", strings.NewReader("rid1\nrid2"), "file", true},
+		{"unsized reader", io.MultiReader(strings.NewReader("rid1\nrid2")), "file", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := api.MultipartFormDataBody{
+				Fields:        []api.FormField{{Name: "type", Value: "attachment"}},
+				Files:         []api.FormFile{{FieldName: "file", FileData: tt.fileData}},
+				FileValidator: &api.FileValidator{MaxSize: 1024, AllowedMimes: []string{"text/plain"}, AllowedExts: []string{".txt", ""}},
+			}
+			resp, err := client.FormRequest(context.Background(), &api.Request{Method: http.MethodPost, URL: srv.URL, Body: body})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := string(resp.RawBody)
+			if !strings.Contains(got, `"data":"rid1`) || !strings.Contains(got, `"name":"`+tt.wantName+`"`) || !strings.Contains(got, `"type":"attachment"`) {
+				t.Errorf("unexpected upload: %s", got)
+			}
+			if knownSize := !strings.Contains(got, `"len":-1`); knownSize != tt.knownSize {
+				t.Errorf("known Content-Length = %v, want %v: %s", knownSize, tt.knownSize, got)
+			}
+		})
+	}
+}
+
+func TestFormRequestValidation(t *testing.T) {
+	srv := newMultipartServer(t)
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+
+	tests := []struct {
+		name     string
+		fileData interface{}
+		wantErr  string
+	}{
+		{"known size too large", bytes.NewReader(make([]byte, 2048)), "exceeds limit"},
+		{"streamed size too large", io.MultiReader(strings.NewReader(strings.Repeat("a", 2048))), "exceeds limit"},
+		{"streamed empty", io.MultiReader(), "is empty"},
+		{"mime not allowed", strings.NewReader("\x89PNG\r\n\x1a\n"), "MIME"},
+	}
+	allowedMimes := []string{"text/plain", "application/octet-stream"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := api.MultipartFormDataBody{
+				Files:         []api.FormFile{{FieldName: "file", FileName: "a.txt", FileData: tt.fileData}},
+				FileValidator: &api.FileValidator{MaxSize: 1024, AllowedMimes: allowedMimes},
+			}
+			_, err := client.FormRequest(context.Background(), &api.Request{Method: http.MethodPost, URL: srv.URL, Body: body})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -39,11 +39,11 @@ func (a *apiv1) CreateApp(ctx context.Context, param *AppCreateParam) (*AppCreat
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  a.proto,
-		URL:    a.host + "/v1/app",
-		Auth:   a.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      a.proto,
+		URL:        a.host + "/v1/app",
+		Authorizer: a.auth,
+		Body:       param,
 	}
 	resp, err := a.client.Request(ctx, req)
 	if err != nil {
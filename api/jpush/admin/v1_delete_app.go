@@ -39,10 +39,10 @@ func (a *apiv1) DeleteApp(ctx context.Context, appKey string) (*AppDeleteResult,
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  a.proto,
-		URL:    a.host + "/v1/app/" + appKey + "/delete",
-		Auth:   a.auth,
+		Method:     http.MethodPost,
+		Proto:      a.proto,
+		URL:        a.host + "/v1/app/" + appKey + "/delete",
+		Authorizer: a.auth,
 	}
 	resp, err := a.client.Request(ctx, req)
 	if err != nil {
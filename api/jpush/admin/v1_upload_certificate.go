@@ -83,11 +83,11 @@ func (a *apiv1) UploadCertificate(ctx context.Context, appKey string, param *Cer
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  a.proto,
-		URL:    a.host + "/v1/app/" + appKey + "/certificate",
-		Auth:   a.auth,
-		Body:   body,
+		Method:     http.MethodPost,
+		Proto:      a.proto,
+		URL:        a.host + "/v1/app/" + appKey + "/certificate",
+		Authorizer: a.auth,
+		Body:       body,
 	}
 	resp, err := a.client.FormRequest(ctx, req)
 	if err != nil {
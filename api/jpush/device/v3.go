@@ -16,7 +16,9 @@ package device
 
 import (
 	"context"
+	"iter"
 
+	"github.com/cavlabs/jiguang-sdk-go/api"
 	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
 )
 
@@ -118,6 +120,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_device#%E8%8E%B7%E5%8F%96%E7%94%A8%E6%88%B7%E5%9C%A8%E7%BA%BF%E7%8A%B6%E6%80%81%EF%BC%88vip%EF%BC%89
 	GetDeviceStatus(ctx context.Context, registrationIDs []string) (*DeviceStatusGetResult, error)
 
+	// # 获取用户在线状态（VIP，自动分块）
+	//  - 功能说明：查询任意数量用户的在线状态，超过 1000 个时自动拆分为多次 GetDeviceStatus 调用，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行并合并结果。
+	//  - 部分分块失败时，返回成功分块的合并结果，以及 api.ChunkErrors 错误，其中每个失败分块的错误为请求错误或 *api.APIError。
+	GetDeviceStatusAll(ctx context.Context, registrationIDs []string, opts ...api.ChunkOption) (map[string]DeviceStatusResult, error)
+
 	// -----------------------------------------------------------------------------------------------------------------
 
 	// # 新增测试设备（VIP）
@@ -154,6 +161,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_device#%E8%8E%B7%E5%8F%96%E6%B5%8B%E8%AF%95%E8%AE%BE%E5%A4%87%E5%88%97%E8%A1%A8
 	ListTestDevices(ctx context.Context, page, pageSize int, deviceName, registrationID string) (*TestDevicesListResult, error)
 
+	// # 遍历所有测试设备（VIP）
+	//  - 功能说明：按页惰性调用 ListTestDevices，逐个产生符合 `filter` 条件（为 nil 时不过滤）的测试设备，可直接用于 range 循环；`opts` 为可选的分页选项，如 api.WithPrefetch() 启用下一页的预取。
+	//  - 拉取失败（包括业务错误，以 *api.APIError 返回）或 ctx 被取消时，迭代器产生一次错误后结束。
+	AllTestDevices(ctx context.Context, filter *TestDeviceFilter, opts ...api.PageOption) iter.Seq2[TestDeviceDetail, error]
+
 	// -----------------------------------------------------------------------------------------------------------------
 
 	// # 查询标签列表
@@ -177,6 +189,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_device#%E6%9B%B4%E6%96%B0%E6%A0%87%E7%AD%BE
 	SetTag(ctx context.Context, tag string, adds, removes []string) (*TagSetResult, error)
 
+	// # 更新标签（自动分块）
+	//  - 功能说明：为一个标签添加或者删除任意数量的设备，`adds`/`removes` 超过 1000 个时自动拆分为多次 SetTag 调用，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行。
+	//  - 部分分块失败时，返回 api.ChunkErrors 错误，其中每个失败分块的错误为请求错误或 *api.APIError。
+	SetTagAll(ctx context.Context, tag string, adds, removes []string, opts ...api.ChunkOption) error
+
 	// # 删除标签
 	//  - 功能说明：删除一个标签，以及标签与设备之间的关联关系。
 	//	- 调用地址：DELETE `/v3/tags/{tag}`，`tag` 为指定的标签值；`plats` 为可选参数，不填则默认为所有平台。
@@ -206,4 +223,9 @@ type APIv3 interface {
 	//  - 接口文档：[docs.jiguang.cn]
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_device#%E5%88%A0%E9%99%A4%E8%AE%BE%E5%A4%87%E7%9A%84%E5%88%AB%E5%90%8D
 	DeleteAliases(ctx context.Context, alias string, registrationIDs []string) (*AliasesDeleteResult, error)
+
+	// # 删除设备的别名（自动分块）
+	//  - 功能说明：批量解绑任意数量的设备与别名之间的关系，`registrationIDs` 超过 1000 个时自动拆分为多次 DeleteAliases 调用，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行。
+	//  - 部分分块失败时，返回 api.ChunkErrors 错误，其中每个失败分块的错误为请求错误或 *api.APIError。
+	DeleteAliasesAll(ctx context.Context, alias string, registrationIDs []string, opts ...api.ChunkOption) error
 }
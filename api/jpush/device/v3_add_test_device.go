@@ -42,11 +42,11 @@ func (d *apiv3) AddTestDevice(ctx context.Context, param *TestDeviceAddParam) (*
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/test/model/add",
-		Auth:   d.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/test/model/add",
+		Authorizer: d.auth,
+		Body:       param,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
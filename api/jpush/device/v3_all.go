@@ -0,0 +1,138 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// # 获取用户在线状态（VIP，自动分块）
+//   - 功能说明：查询任意数量用户的在线状态，`registrationIDs` 会被拆分为每块最多 1000 个，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数分别调用 GetDeviceStatus 并合并结果。
+//   - 部分分块失败时，返回成功分块的合并结果，以及 api.ChunkErrors 错误（其中的 Start/End 为分块在 `registrationIDs` 中的位置）。
+func (d *apiv3) GetDeviceStatusAll(ctx context.Context, registrationIDs []string, opts ...api.ChunkOption) (map[string]DeviceStatusResult, error) {
+	if d == nil {
+		return nil, api.ErrNilJPushDeviceAPIv3
+	}
+	if len(registrationIDs) == 0 {
+		return nil, errors.New("`registrationIDs` cannot be empty")
+	}
+
+	var mu sync.Mutex
+	merged := make(map[string]DeviceStatusResult, len(registrationIDs))
+	err := api.ForEachChunk(ctx, registrationIDs, 1000, func(ctx context.Context, _ int, chunk []string) error {
+		result, err := d.GetDeviceStatus(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		if !result.IsSuccess() {
+			return api.NewAPIError(http.MethodPost+" /v3/devices/status", result.Response, result.Error)
+		}
+		mu.Lock()
+		for rid, status := range result.Result {
+			merged[rid] = status
+		}
+		mu.Unlock()
+		return nil
+	}, opts...)
+	return merged, err
+}
+
+// # 更新标签（自动分块）
+//   - 功能说明：为一个标签添加或者删除任意数量的设备，`adds`/`removes` 会被分别拆分为每块最多 1000 个，第 i 块的添加与删除合并在同一次 SetTag 调用中，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行。
+//   - 部分分块失败时，返回 api.ChunkErrors 错误（其中的 Start/End 为分块在 `adds` 和 `removes` 中的位置）。
+func (d *apiv3) SetTagAll(ctx context.Context, tag string, adds, removes []string, opts ...api.ChunkOption) error {
+	if d == nil {
+		return api.ErrNilJPushDeviceAPIv3
+	}
+	if tag == "" {
+		return errors.New("`tag` cannot be empty")
+	}
+	if len(adds) == 0 && len(removes) == 0 {
+		return errors.New("`adds` and `removes` cannot both be empty")
+	}
+
+	addChunks, removeChunks := api.Chunk(adds, 1000), api.Chunk(removes, 1000)
+	indexes := make([]int, max(len(addChunks), len(removeChunks)))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	err := api.ForEachChunk(ctx, indexes, 1, func(ctx context.Context, i int, _ []int) error {
+		a, r := chunkAt(addChunks, i), chunkAt(removeChunks, i)
+		result, err := d.SetTag(ctx, tag, a, r)
+		if err != nil {
+			return err
+		}
+		if !result.IsSuccess() {
+			e := api.NewAPIError(http.MethodPost+" /v3/tags/"+tag, result.Response, nil)
+			if result.Error != nil {
+				e.CodeError = &result.Error.CodeError // 非法 Registration ID 集合可从 e.RawBody 中获取
+			}
+			return e
+		}
+		return nil
+	}, opts...)
+
+	// 将分块在 `indexes` 中的位置还原为在 `adds` 和 `removes` 中的位置。
+	var chunkErrs api.ChunkErrors
+	if errors.As(err, &chunkErrs) {
+		for _, e := range chunkErrs {
+			e.Start = e.Index * 1000
+			e.End = e.Start + max(len(chunkAt(addChunks, e.Index)), len(chunkAt(removeChunks, e.Index)))
+		}
+	}
+	return err
+}
+
+func chunkAt(chunks [][]string, i int) []string {
+	if i < len(chunks) {
+		return chunks[i]
+	}
+	return nil
+}
+
+// # 删除设备的别名（自动分块）
+//   - 功能说明：批量解绑任意数量的设备与别名之间的关系，`registrationIDs` 会被拆分为每块最多 1000 个，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数分别调用 DeleteAliases。
+//   - 部分分块失败时，返回 api.ChunkErrors 错误（其中的 Start/End 为分块在 `registrationIDs` 中的位置）。
+func (d *apiv3) DeleteAliasesAll(ctx context.Context, alias string, registrationIDs []string, opts ...api.ChunkOption) error {
+	if d == nil {
+		return api.ErrNilJPushDeviceAPIv3
+	}
+	if alias == "" {
+		return errors.New("`alias` cannot be empty")
+	}
+	if len(registrationIDs) == 0 {
+		return errors.New("`registrationIDs` cannot be empty")
+	}
+
+	return api.ForEachChunk(ctx, registrationIDs, 1000, func(ctx context.Context, _ int, chunk []string) error {
+		result, err := d.DeleteAliases(ctx, alias, chunk)
+		if err != nil {
+			return err
+		}
+		if !result.IsSuccess() {
+			e := api.NewAPIError(http.MethodPost+" /v3/aliases/"+alias, result.Response, nil)
+			if result.Error != nil {
+				e.CodeError = &result.Error.CodeError
+			}
+			return e
+		}
+		return nil
+	}, opts...)
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+func TestSetTagAll_PartialFailure(t *testing.T) {
+	srv := jiguangtest.NewServer()
+	defer srv.Close()
+
+	rids := make([]string, 2500)
+	for i := range rids {
+		rids[i] = fmt.Sprintf("rid%04d", i)
+		if i != 2100 { // 第 3 个分块中包含一个不存在的设备
+			srv.AddDevice(jiguangtest.Device{RegistrationID: rids[i], Platform: platform.Android})
+		}
+	}
+
+	deviceAPIv3, err := sdk.NewClient(srv.Config()).Device()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = deviceAPIv3.SetTagAll(context.Background(), "vip", rids, nil)
+
+	var chunkErrs api.ChunkErrors
+	if !errors.As(err, &chunkErrs) || len(chunkErrs) != 1 {
+		t.Fatalf("expected one chunk error, got %v", err)
+	}
+	if e := chunkErrs[0]; e.Index != 2 || e.Start != 2000 || e.End != 2500 {
+		t.Fatalf("unexpected chunk error: %+v", e)
+	}
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected an API error for the failed chunk, got %v", err)
+	}
+
+	if n := len(srv.RequestsTo(http.MethodPost, "/v3/tags/vip")); n != 3 {
+		t.Fatalf("expected 3 chunked requests, got %d", n)
+	}
+	if d, _ := srv.Device(rids[1999]); len(d.Tags) != 1 {
+		t.Fatalf("expected devices of successful chunks to be tagged, got %+v", d)
+	}
+	if d, _ := srv.Device(rids[2000]); len(d.Tags) != 0 {
+		t.Fatalf("expected devices of the failed chunk to be untouched, got %+v", d)
+	}
+}
+
+func TestDeleteAliasesAll(t *testing.T) {
+	srv := jiguangtest.NewServer()
+	defer srv.Close()
+
+	rids := make([]string, 1200)
+	for i := range rids {
+		rids[i] = fmt.Sprintf("rid%04d", i)
+		srv.AddDevice(jiguangtest.Device{RegistrationID: rids[i], Platform: platform.IOS, Alias: "alice"})
+	}
+
+	deviceAPIv3, err := sdk.NewClient(srv.Config()).Device()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = deviceAPIv3.DeleteAliasesAll(context.Background(), "alice", rids); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(srv.RequestsTo(http.MethodPost, "/v3/aliases/alice")); n != 2 {
+		t.Fatalf("expected 2 chunked requests, got %d", n)
+	}
+	if d, _ := srv.Device(rids[1199]); d.Alias != "" {
+		t.Fatalf("expected alias to be removed, got %+v", d)
+	}
+}
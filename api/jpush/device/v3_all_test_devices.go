@@ -0,0 +1,58 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// 测试设备列表的查询条件，提供给 AllTestDevices 使用。
+type TestDeviceFilter struct {
+	DeviceName     string // 【可选】开发者自定义的设备名称（模糊查询），与 RegistrationID 同时存在时只会使用 DeviceName
+	RegistrationID string // 【可选】设备标识 Registration ID（精确查询）
+	PageSize       int    // 【可选】每页记录条数，不大于 0 时默认为 200
+}
+
+// # 遍历所有测试设备（VIP）
+//   - 功能说明：按页惰性调用 ListTestDevices，逐个产生符合 `filter` 条件的测试设备，可直接用于 range 循环；
+//     `filter` 为 nil 时表示不过滤；`opts` 为可选的分页选项，如 api.WithPrefetch() 启用下一页的预取。
+//   - 拉取失败（包括业务错误，以 *api.APIError 返回）或 ctx 被取消时，迭代器产生一次错误后结束。
+func (d *apiv3) AllTestDevices(ctx context.Context, filter *TestDeviceFilter, opts ...api.PageOption) iter.Seq2[TestDeviceDetail, error] {
+	var f TestDeviceFilter
+	if filter != nil {
+		f = *filter
+	}
+	if f.PageSize <= 0 {
+		f.PageSize = 200
+	}
+
+	return api.Paginate(ctx, func(ctx context.Context, page int) ([]TestDeviceDetail, bool, error) {
+		if d == nil {
+			return nil, false, api.ErrNilJPushDeviceAPIv3
+		}
+		result, err := d.ListTestDevices(ctx, page, f.PageSize, f.DeviceName, f.RegistrationID)
+		if err != nil {
+			return nil, false, err
+		}
+		if !result.IsSuccess() {
+			return nil, false, api.NewAPIError(http.MethodGet+" /v3/test/model/list", result.Response, result.Error)
+		}
+		return result.Detail, len(result.Detail) > 0 && page*f.PageSize < result.Total, nil
+	}, opts...)
+}
@@ -105,11 +105,11 @@ func (d *apiv3) clearDevice(ctx context.Context, registrationID string, clearTag
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/devices/" + registrationID,
-		Auth:   d.auth,
-		Body:   newDeviceClearParam(clearTags, clearAlias, clearMobile),
+		Method:     http.MethodPost,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/devices/" + registrationID,
+		Authorizer: d.auth,
+		Body:       newDeviceClearParam(clearTags, clearAlias, clearMobile),
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
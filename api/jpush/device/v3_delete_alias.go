@@ -40,10 +40,10 @@ func (d *apiv3) DeleteAlias(ctx context.Context, alias string, plats ...platform
 	}
 
 	req := &api.Request{
-		Method: http.MethodDelete,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/aliases/" + alias + "?platform=" + platform.Concat(plats, ","),
-		Auth:   d.auth,
+		Method:     http.MethodDelete,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/aliases/" + alias + "?platform=" + platform.Concat(plats, ","),
+		Authorizer: d.auth,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
@@ -48,11 +48,11 @@ func (d *apiv3) DeleteAliases(ctx context.Context, alias string, registrationIDs
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/aliases/" + alias,
-		Auth:   d.auth,
-		Body:   &aliasesDeleteParam{registrationIDsForAliasesDeleteParam{registrationIDs}},
+		Method:     http.MethodPost,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/aliases/" + alias,
+		Authorizer: d.auth,
+		Body:       &aliasesDeleteParam{registrationIDsForAliasesDeleteParam{registrationIDs}},
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
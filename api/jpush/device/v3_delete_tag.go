@@ -40,10 +40,10 @@ func (d *apiv3) DeleteTag(ctx context.Context, tag string, plats ...platform.Pla
 	}
 
 	req := &api.Request{
-		Method: http.MethodDelete,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/tags/" + tag + "?platform=" + platform.Concat(plats, ","),
-		Auth:   d.auth,
+		Method:     http.MethodDelete,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/tags/" + tag + "?platform=" + platform.Concat(plats, ","),
+		Authorizer: d.auth,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
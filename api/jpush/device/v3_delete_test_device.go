@@ -39,10 +39,10 @@ func (d *apiv3) DeleteTestDevice(ctx context.Context, registrationID string) (*T
 	}
 
 	req := &api.Request{
-		Method: http.MethodDelete,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/test/model/delete/" + registrationID,
-		Auth:   d.auth,
+		Method:     http.MethodDelete,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/test/model/delete/" + registrationID,
+		Authorizer: d.auth,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
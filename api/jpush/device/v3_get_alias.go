@@ -41,10 +41,10 @@ func (d *apiv3) GetAlias(ctx context.Context, alias string, plats ...platform.Pl
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/aliases/" + alias + "?platform=" + platform.Concat(plats, ",") + "&new_format=true",
-		Auth:   d.auth,
+		Method:     http.MethodGet,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/aliases/" + alias + "?platform=" + platform.Concat(plats, ",") + "&new_format=true",
+		Authorizer: d.auth,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
@@ -39,10 +39,10 @@ func (d *apiv3) GetDevice(ctx context.Context, registrationID string) (*DeviceGe
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/devices/" + registrationID,
-		Auth:   d.auth,
+		Method:     http.MethodGet,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/devices/" + registrationID,
+		Authorizer: d.auth,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
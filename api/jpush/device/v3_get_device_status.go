@@ -44,11 +44,11 @@ func (d *apiv3) GetDeviceStatus(ctx context.Context, registrationIDs []string) (
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/devices/status",
-		Auth:   d.auth,
-		Body:   &deviceStatusGetParam{RegistrationIDs: registrationIDs},
+		Method:     http.MethodPost,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/devices/status",
+		Authorizer: d.auth,
+		Body:       &deviceStatusGetParam{RegistrationIDs: registrationIDs},
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
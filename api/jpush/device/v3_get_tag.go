@@ -42,10 +42,10 @@ func (d *apiv3) GetTag(ctx context.Context, tag string, registrationID string) (
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/tags/" + tag + "/registration_ids/" + registrationID,
-		Auth:   d.auth,
+		Method:     http.MethodGet,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/tags/" + tag + "/registration_ids/" + registrationID,
+		Authorizer: d.auth,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
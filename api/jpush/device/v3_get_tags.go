@@ -34,10 +34,10 @@ func (d *apiv3) GetTags(ctx context.Context) (*TagsGetResult, error) {
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/tags",
-		Auth:   d.auth,
+		Method:     http.MethodGet,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/tags",
+		Authorizer: d.auth,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
@@ -68,10 +68,10 @@ func (d *apiv3) ListTestDevices(ctx context.Context, page, pageSize int, deviceN
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/test/model/list" + query,
-		Auth:   d.auth,
+		Method:     http.MethodGet,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/test/model/list" + query,
+		Authorizer: d.auth,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
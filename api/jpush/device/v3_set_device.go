@@ -43,11 +43,11 @@ func (d *apiv3) SetDevice(ctx context.Context, registrationID string, param *Dev
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/devices/" + registrationID,
-		Auth:   d.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/devices/" + registrationID,
+		Authorizer: d.auth,
+		Body:       param,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
@@ -51,11 +51,11 @@ func (d *apiv3) SetTag(ctx context.Context, tag string, adds, removes []string)
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/tags/" + tag,
-		Auth:   d.auth,
-		Body:   &tagSetParam{RegistrationIDs: registrationIDsForTagSetParam{Add: adds, Remove: removes}},
+		Method:     http.MethodPost,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/tags/" + tag,
+		Authorizer: d.auth,
+		Body:       &tagSetParam{RegistrationIDs: registrationIDsForTagSetParam{Add: adds, Remove: removes}},
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
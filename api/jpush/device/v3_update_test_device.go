@@ -39,11 +39,11 @@ func (d *apiv3) UpdateTestDevice(ctx context.Context, param *TestDeviceUpdatePar
 	}
 
 	req := &api.Request{
-		Method: http.MethodPut,
-		Proto:  d.proto,
-		URL:    d.host + "/v3/test/model/update",
-		Auth:   d.auth,
-		Body:   param,
+		Method:     http.MethodPut,
+		Proto:      d.proto,
+		URL:        d.host + "/v3/test/model/update",
+		Authorizer: d.auth,
+		Body:       param,
 	}
 	resp, err := d.client.Request(ctx, req)
 	if err != nil {
@@ -14,7 +14,10 @@
 
 package file
 
-import "context"
+import (
+	"context"
+	"iter"
+)
 
 // # File API v3
 //
@@ -45,6 +48,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_file#%E6%9F%A5%E8%AF%A2%E6%9C%89%E6%95%88%E6%96%87%E4%BB%B6%E5%88%97%E8%A1%A8
 	GetFiles(ctx context.Context) (*FilesGetResult, error)
 
+	// # 遍历所有有效文件
+	//  - 功能说明：调用 GetFiles，逐个产生当前保存在极光服务器的有效文件，可直接用于 range 循环。
+	//  - 拉取失败（包括业务错误，以 *api.APIError 返回）或 ctx 被取消时，迭代器产生一次错误后结束。
+	AllFiles(ctx context.Context) iter.Seq2[FileGetResult, error]
+
 	// # 查询指定文件详情
 	//  - 功能说明：查询保存在极光服务器的，指定文件的详细信息。
 	//	- 调用地址：GET `/v3/files/{fileID}`
@@ -0,0 +1,43 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// # 遍历所有有效文件
+//   - 功能说明：调用 GetFiles，逐个产生当前保存在极光服务器的有效文件，可直接用于 range 循环；
+//     该接口不分页，提供迭代器是为了与其它列表接口的遍历方式保持一致。
+//   - 拉取失败（包括业务错误，以 *api.APIError 返回）或 ctx 被取消时，迭代器产生一次错误后结束。
+func (f *apiv3) AllFiles(ctx context.Context) iter.Seq2[FileGetResult, error] {
+	return api.Paginate(ctx, func(ctx context.Context, _ int) ([]FileGetResult, bool, error) {
+		if f == nil {
+			return nil, false, api.ErrNilJPushFileAPIv3
+		}
+		result, err := f.GetFiles(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		if !result.IsSuccess() {
+			return nil, false, api.NewAPIError(http.MethodGet+" /v3/files", result.Response, result.Error)
+		}
+		return result.Files, false, nil
+	})
+}
@@ -44,10 +44,10 @@ func (f *apiv3) DeleteFile(ctx context.Context, fileID string) (*FileDeleteResul
 	}
 
 	req := &api.Request{
-		Method: http.MethodDelete,
-		Proto:  f.proto,
-		URL:    f.host + "/v3/files/" + fileID,
-		Auth:   f.auth,
+		Method:     http.MethodDelete,
+		Proto:      f.proto,
+		URL:        f.host + "/v3/files/" + fileID,
+		Authorizer: f.auth,
 	}
 	resp, err := f.client.Request(ctx, req)
 	if err != nil {
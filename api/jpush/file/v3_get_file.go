@@ -40,10 +40,10 @@ func (f *apiv3) GetFile(ctx context.Context, fileID string) (*FileGetResult, err
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  f.proto,
-		URL:    f.host + "/v3/files/" + fileID,
-		Auth:   f.auth,
+		Method:     http.MethodGet,
+		Proto:      f.proto,
+		URL:        f.host + "/v3/files/" + fileID,
+		Authorizer: f.auth,
 	}
 	resp, err := f.client.Request(ctx, req)
 	if err != nil {
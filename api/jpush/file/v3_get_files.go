@@ -34,10 +34,10 @@ func (f *apiv3) GetFiles(ctx context.Context) (*FilesGetResult, error) {
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  f.proto,
-		URL:    f.host + "/v3/files",
-		Auth:   f.auth,
+		Method:     http.MethodGet,
+		Proto:      f.proto,
+		URL:        f.host + "/v3/files",
+		Authorizer: f.auth,
 	}
 	resp, err := f.client.Request(ctx, req)
 	if err != nil {
@@ -75,11 +75,11 @@ func (f *apiv3) uploadFile(ctx context.Context, forType string, param *FileUploa
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  f.proto,
-		URL:    f.host + "/v3/files/" + forType,
-		Auth:   f.auth,
-		Body:   body,
+		Method:     http.MethodPost,
+		Proto:      f.proto,
+		URL:        f.host + "/v3/files/" + forType,
+		Authorizer: f.auth,
+		Body:       body,
 	}
 	resp, err := f.client.FormRequest(ctx, req)
 	if err != nil {
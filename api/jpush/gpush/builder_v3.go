@@ -15,7 +15,6 @@
 package gpush
 
 import (
-	"encoding/base64"
 	"errors"
 
 	"github.com/cavlabs/jiguang-sdk-go/api"
@@ -25,15 +24,22 @@ import (
 
 // 用于构建和配置 Group Push API v3 访问客户端的构建器。
 type APIv3Builder struct {
-	client            api.Client
-	host              string
-	groupKey          string
-	groupMasterSecret string
-	devKey            string
-	devSecret         string
-	logger            jiguang.Logger
-	httpLogLevel      api.HttpLogLevel
-	err               error
+	client              api.Client
+	httpClient          api.HttpClient
+	credentialsProvider api.CredentialsProvider
+	host                string
+	proto               string
+	groupKey            string
+	groupMasterSecret   string
+	devKey              string
+	devSecret           string
+	logger              jiguang.Logger
+	httpLogLevel        api.HttpLogLevel
+	retryPolicy         *api.RetryPolicy
+	rateLimiter         *api.RateLimiter
+	middlewares         []api.Middleware
+	strictErrors        bool
+	err                 error
 }
 
 func NewAPIv3Builder() *APIv3Builder {
@@ -51,6 +57,14 @@ func (b *APIv3Builder) SetClient(client api.Client) *APIv3Builder {
 	return b
 }
 
+// 【可选】设置 API 的 HTTP 客户端，用于在多个 API 之间共享同一个 api.HttpClient，可使用 api.NewHttpClient 创建。
+//   - 设置后将忽略 SetClient、SetLogger（回调接口服务除外）、SetHttpLogLevel、SetRetryPolicy、SetRateLimiter、SetMiddlewares 和 EnableStrictErrors 的设置，
+//     它们均以该 HTTP 客户端创建时的设置为准。
+func (b *APIv3Builder) SetHttpClient(httpClient api.HttpClient) *APIv3Builder {
+	b.httpClient = httpClient
+	return b
+}
+
 // 【可选】设置 API 的 Host 基础 URL，默认为 api.HostJPushPushV3。
 func (b *APIv3Builder) SetHost(host string) *APIv3Builder {
 	if host == "" {
@@ -60,6 +74,14 @@ func (b *APIv3Builder) SetHost(host string) *APIv3Builder {
 	return b
 }
 
+// 【可选】设置 API 的 HTTP 协议版本，如 "HTTP/1.1"、"HTTP/2.0" 等，设置后将跳过协议版本探测。
+//   - 默认为空，即在首次发送请求时于后台探测（按 Host 缓存并在进程内共享，探测完成前使用 "HTTP/1.1"），Build 不会发起任何网络请求；
+//   - 可使用 api.HttpClient 的 DetectProto 方法主动探测。
+func (b *APIv3Builder) SetProto(proto string) *APIv3Builder {
+	b.proto = proto
+	return b
+}
+
 // 【必填】设置 API 的应用分组标识。
 func (b *APIv3Builder) SetGroupKey(groupKey string) *APIv3Builder {
 	if groupKey == "" {
@@ -78,6 +100,14 @@ func (b *APIv3Builder) SetGroupMasterSecret(groupMasterSecret string) *APIv3Buil
 	return b
 }
 
+// 【可选】设置 API 的凭证提供者，每次发送请求时都会调用它获取 `groupKey` 和 `groupMasterSecret`，以支持密钥的热轮换。
+//   - 设置后将忽略 SetGroupKey 和 SetGroupMasterSecret 的设置；
+//   - 可使用 api.StaticCredentials、api.EnvCredentials、api.FileCredentials 和 api.CachedCredentials 等，详见 api.CredentialsProvider 说明。
+func (b *APIv3Builder) SetCredentialsProvider(provider api.CredentialsProvider) *APIv3Builder {
+	b.credentialsProvider = provider
+	return b
+}
+
 // 【可选】设置 API 的开发者标识。
 //   - 当需要同时使用 “上传文件” 等相关「文件管理」的 API 接口时，请务必同时设置 `devKey`；
 //   - 详见 [docs.jiguang.cn] 文档说明。
@@ -124,33 +154,73 @@ func (b *APIv3Builder) DisableHttpLogging() *APIv3Builder {
 	return b
 }
 
+// 【可选】设置 API 的 HTTP 请求重试策略，用于在遇到网络错误、5xx 服务端错误或 429 请求频率超限时自动重试，默认不重试。
+//   - 可使用 api.DefaultRetryPolicy 获取默认的重试策略（最多尝试 3 次，带抖动的指数退避）；
+//   - 分组推送等非幂等请求仅在遇到 429 时重试，详见 api.RetryPolicy 说明。
+func (b *APIv3Builder) SetRetryPolicy(retryPolicy *api.RetryPolicy) *APIv3Builder {
+	b.retryPolicy = retryPolicy
+	return b
+}
+
+// 【可选】设置 API 的客户端频率限制器，用于根据 `X-Rate-Limit-*` 响应头在客户端主动排队等待，避免触发 429，默认不限制。
+//   - 可使用 api.NewRateLimiter 创建，并在共享同一配额的多个 API 之间共享同一个实例；
+//   - 详见 api.RateLimiter 说明。
+func (b *APIv3Builder) SetRateLimiter(rateLimiter *api.RateLimiter) *APIv3Builder {
+	b.rateLimiter = rateLimiter
+	return b
+}
+
+// 【可选】设置 API 的请求/响应中间件，可用于审计、注入请求头、采集指标和故障注入等，按照设置的顺序由外向内依次执行。
+//   - 中间件可以检查和修改请求、直接返回合成的响应，以及观察响应结果和耗时；
+//   - 详见 api.Middleware 说明。
+func (b *APIv3Builder) SetMiddlewares(middlewares ...api.Middleware) *APIv3Builder {
+	b.middlewares = middlewares
+	return b
+}
+
+// 【可选】启用严格错误模式，即 API 调用失败时（响应状态码不是 2xx 或错误码不为 0）直接返回 *api.APIError 错误，而不是返回 IsSuccess() 为 false 的结果。
+//   - 可通过 errors.Is 判断错误类别，如 errors.Is(err, api.ErrAuthFailed)、errors.Is(err, api.ErrRateLimited) 等；
+//   - 详见 api.APIError 说明。
+func (b *APIv3Builder) EnableStrictErrors() *APIv3Builder {
+	b.strictErrors = true
+	return b
+}
+
 func (b *APIv3Builder) Build() (APIv3, error) {
 	if b.err != nil {
 		return (*apiv3)(nil), b.err
 	}
-	if b.groupKey == "" || b.groupMasterSecret == "" {
+	if b.credentialsProvider == nil && (b.groupKey == "" || b.groupMasterSecret == "") {
 		return (*apiv3)(nil), errors.New("both `groupKey` and `groupMasterSecret` cannot be empty")
 	}
 
-	client := api.NewHttpClient(b.client, b.logger, b.httpLogLevel)
-	proto := client.DetectProto(b.host)
-	creds := base64.StdEncoding.EncodeToString([]byte("group-" + b.groupKey + ":" + b.groupMasterSecret))
+	client := b.httpClient
+	if client == nil {
+		client = api.NewHttpClient(b.client, b.logger, b.httpLogLevel,
+			api.WithRetryPolicy(b.retryPolicy),
+			api.WithRateLimiter(b.rateLimiter),
+			api.WithMiddlewares(b.middlewares...),
+			api.WithStrictErrors(b.strictErrors))
+	}
+	credentialsProvider := b.credentialsProvider
+	if credentialsProvider == nil {
+		credentialsProvider = api.StaticCredentials(b.groupKey, b.groupMasterSecret)
+	}
 
 	filev3, _ := file.NewAPIv3Builder().
-		SetClient(b.client).
+		SetHttpClient(client).
 		SetHost(b.host).
+		SetProto(b.proto).
 		SetAuthKey(b.devKey).
 		SetAuthSecret(b.devSecret).
-		SetLogger(b.logger).
-		SetHttpLogLevel(b.httpLogLevel).
 		Build()
 
 	return &apiv3{
 		fileAPIv3: filev3,
 		client:    client,
-		proto:     proto,
+		proto:     b.proto,
 		host:      b.host,
-		auth:      "Basic " + creds,
+		auth:      api.BasicAuth(credentialsProvider, "group-"),
 	}, nil
 }
 
@@ -160,5 +230,5 @@ type apiv3 struct {
 	client api.HttpClient
 	proto  string
 	host   string
-	auth   string
+	auth   api.Authorizer
 }
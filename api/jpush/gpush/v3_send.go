@@ -51,11 +51,11 @@ func (gp *apiv3) CustomSend(ctx context.Context, param interface{}) (*SendResult
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  gp.proto,
-		URL:    gp.host + "/v3/grouppush",
-		Auth:   gp.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      gp.proto,
+		URL:        gp.host + "/v3/grouppush",
+		Authorizer: gp.auth,
+		Body:       param,
 	}
 	resp, err := gp.client.Request(ctx, req)
 	if err != nil {
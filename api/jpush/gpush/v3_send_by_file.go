@@ -51,11 +51,11 @@ func (gp *apiv3) CustomSendByFile(ctx context.Context, param interface{}) (*Send
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  gp.proto,
-		URL:    gp.host + "/v3/grouppush/file",
-		Auth:   gp.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      gp.proto,
+		URL:        gp.host + "/v3/grouppush/file",
+		Authorizer: gp.auth,
+		Body:       param,
 	}
 	resp, err := gp.client.Request(ctx, req)
 	if err != nil {
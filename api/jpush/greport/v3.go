@@ -17,6 +17,7 @@ package greport
 import (
 	"context"
 
+	"github.com/cavlabs/jiguang-sdk-go/api"
 	"github.com/cavlabs/jiguang-sdk-go/jiguang"
 )
 
@@ -35,6 +36,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_report#%E5%88%86%E7%BB%84%E7%BB%9F%E8%AE%A1-%E6%B6%88%E6%81%AF%E7%BB%9F%E8%AE%A1%EF%BC%88vip%EF%BC%89
 	GetMessageDetail(ctx context.Context, groupMsgIDs []string) (*MessageDetailGetResult, error)
 
+	// # 消息统计详情（VIP，自动分块）
+	//  - 功能说明：针对分组应用，获取任意数量 groupMsgIDs 的消息统计数据，超过 10 个时自动拆分为多次 GetMessageDetail 调用，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行，并按 `groupMsgIDs` 的顺序合并结果。
+	//  - 部分分块失败时，返回成功分块的合并结果，以及 api.ChunkErrors 错误，其中每个失败分块的错误为请求错误或 *api.APIError。
+	GetMessageDetailAll(ctx context.Context, groupMsgIDs []string, opts ...api.ChunkOption) ([]MessageDetail, error)
+
 	// # 用户统计（VIP）
 	//  - 功能说明：针对分组应用，提供近 1 个月内某时间段的用户相关统计数据：新增用户、在线用户、活跃用户。
 	//  `start` 起始时间，它的时间单位支持：HOUR（小时，格式例：2020-08-11 09）、DAY（天，格式例：2020-08-11）、MONTH（月，格式例：2020-08）；
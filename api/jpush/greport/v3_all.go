@@ -0,0 +1,50 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package greport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// # 消息统计详情（VIP，自动分块）
+//   - 功能说明：针对分组应用，获取任意数量 groupMsgIDs 的消息统计数据，`groupMsgIDs` 会被拆分为每块最多 10 个，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数分别调用 GetMessageDetail，并按 `groupMsgIDs` 的顺序合并结果。
+//   - 部分分块失败时，返回成功分块的合并结果，以及 api.ChunkErrors 错误（其中的 Start/End 为分块在 `groupMsgIDs` 中的位置）。
+func (gr *apiv3) GetMessageDetailAll(ctx context.Context, groupMsgIDs []string, opts ...api.ChunkOption) ([]MessageDetail, error) {
+	if gr == nil {
+		return nil, api.ErrNilJPushGroupReportAPIv3
+	}
+	if len(groupMsgIDs) == 0 {
+		return nil, errors.New("`groupMsgIDs` cannot be empty")
+	}
+
+	chunks := make([][]MessageDetail, (len(groupMsgIDs)+9)/10)
+	err := api.ForEachChunk(ctx, groupMsgIDs, 10, func(ctx context.Context, i int, chunk []string) error {
+		result, err := gr.GetMessageDetail(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		if !result.IsSuccess() {
+			return api.NewAPIError(http.MethodGet+" /v3/group/messages/detail", result.Response, result.Error)
+		}
+		chunks[i] = result.MessageDetails
+		return nil
+	}, opts...)
+	return slices.Concat(chunks...), err
+}
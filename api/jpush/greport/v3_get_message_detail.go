@@ -45,10 +45,10 @@ func (gr *apiv3) GetMessageDetail(ctx context.Context, groupMsgIDs []string) (*M
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  gr.proto,
-		URL:    gr.host + "/v3/group/messages/detail?group_msgids=" + strings.Join(groupMsgIDs, ","),
-		Auth:   gr.auth,
+		Method:     http.MethodGet,
+		Proto:      gr.proto,
+		URL:        gr.host + "/v3/group/messages/detail?group_msgids=" + strings.Join(groupMsgIDs, ","),
+		Authorizer: gr.auth,
 	}
 	resp, err := gr.client.Request(ctx, req)
 	if err != nil {
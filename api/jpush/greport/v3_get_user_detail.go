@@ -61,10 +61,10 @@ func (gr *apiv3) GetUserDetail(ctx context.Context, start jiguang.UnitTime, dura
 
 	query := "?time_unit=" + tu.String() + "&start=" + url.QueryEscape(start.Format()) + "&duration=" + strconv.Itoa(duration)
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  gr.proto,
-		URL:    gr.host + "/v3/group/users" + query,
-		Auth:   gr.auth,
+		Method:     http.MethodGet,
+		Proto:      gr.proto,
+		URL:        gr.host + "/v3/group/users" + query,
+		Authorizer: gr.auth,
 	}
 	resp, err := gr.client.Request(ctx, req)
 	if err != nil {
@@ -76,11 +76,11 @@ func (i *apiv3) AddImageByFile(ctx context.Context, param *AddByFileParam) (*Add
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  i.proto,
-		URL:    i.host + "/v3/images/byfiles",
-		Auth:   i.auth,
-		Body:   body,
+		Method:     http.MethodPost,
+		Proto:      i.proto,
+		URL:        i.host + "/v3/images/byfiles",
+		Authorizer: i.auth,
+		Body:       body,
 	}
 	resp, err := i.client.FormRequest(ctx, req)
 	if err != nil {
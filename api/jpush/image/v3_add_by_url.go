@@ -43,11 +43,11 @@ func (i *apiv3) AddImageByUrl(ctx context.Context, param *AddByUrlParam) (*AddBy
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  i.proto,
-		URL:    i.host + "/v3/images/byurls",
-		Auth:   i.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      i.proto,
+		URL:        i.host + "/v3/images/byurls",
+		Authorizer: i.auth,
+		Body:       param,
 	}
 	resp, err := i.client.Request(ctx, req)
 	if err != nil {
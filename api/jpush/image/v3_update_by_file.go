@@ -74,11 +74,11 @@ func (i *apiv3) UpdateImageByFile(ctx context.Context, mediaID string, param *Up
 	}
 
 	req := &api.Request{
-		Method: http.MethodPut,
-		Proto:  i.proto,
-		URL:    i.host + "/v3/images/byfiles/" + mediaID,
-		Auth:   i.auth,
-		Body:   body,
+		Method:     http.MethodPut,
+		Proto:      i.proto,
+		URL:        i.host + "/v3/images/byfiles/" + mediaID,
+		Authorizer: i.auth,
+		Body:       body,
 	}
 	resp, err := i.client.FormRequest(ctx, req)
 	if err != nil {
@@ -42,11 +42,11 @@ func (i *apiv3) UpdateImageByUrl(ctx context.Context, mediaID string, param *Upd
 	}
 
 	req := &api.Request{
-		Method: http.MethodPut,
-		Proto:  i.proto,
-		URL:    i.host + "/v3/images/byurls/" + mediaID,
-		Auth:   i.auth,
-		Body:   param,
+		Method:     http.MethodPut,
+		Proto:      i.proto,
+		URL:        i.host + "/v3/images/byurls/" + mediaID,
+		Authorizer: i.auth,
+		Body:       param,
 	}
 	resp, err := i.client.Request(ctx, req)
 	if err != nil {
@@ -0,0 +1,254 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// 推送合并器已关闭，不再接受新的推送。
+var ErrAggregatorClosed = errors.New("push aggregator is closed")
+
+// # 推送合并器
+//
+// 将短时间窗口内推送内容完全相同（Platform、Notification、CustomMessage、Options、Class 等除 Audience 和 CID 之外的全部参数）、
+// 并且只按 Registration ID 或只按别名推送的多次 Send 合并为一次推送，以减少 `/v3/push` 的调用次数：
+//   - 同一批次的推送目标会被去重，数量达到上限（默认 1000）时立即发送该批次；
+//   - 合并后推送的结果（MsgID 或错误）会被分发给该批次的每一个调用方，各调用方得到的是同一个 *SendResult，应当只读；
+//   - 不满足合并条件的推送（如设置了 CID、按标签推送或广播等）直接调用 APIv3.Send 发送。
+//
+// 可被多个 goroutine 并发使用。
+type Aggregator struct {
+	api        APIv3
+	window     time.Duration
+	maxTargets int
+
+	mu      sync.Mutex
+	batches map[string]*aggregateBatch
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// 一个等待合并发送的批次。
+type aggregateBatch struct {
+	key      string
+	param    *SendParam // 除 Audience 外的推送参数
+	byAlias  bool
+	targets  []string
+	seen     map[string]bool
+	waiters  []chan aggregateResult
+	timer    *time.Timer
+	flushing bool
+}
+
+type aggregateResult struct {
+	result *SendResult
+	err    error
+}
+
+// 用于配置 Aggregator 的选项。
+type AggregatorOption func(*Aggregator)
+
+// 设置合并的时间窗口，即批次中的第一个推送最多等待的时长，默认为 50ms。
+func WithAggregateWindow(window time.Duration) AggregatorOption {
+	return func(a *Aggregator) {
+		if window > 0 {
+			a.window = window
+		}
+	}
+}
+
+// 设置单次合并推送的最大目标数量，默认为 1000（即 Registration ID 或别名的上限）。
+func WithAggregateMaxTargets(n int) AggregatorOption {
+	return func(a *Aggregator) {
+		if n > 0 && n <= 1000 {
+			a.maxTargets = n
+		}
+	}
+}
+
+// 创建推送合并器，使用完毕后需要调用 Close 发送所有等待中的批次。
+func NewAggregator(pushAPIv3 APIv3, opts ...AggregatorOption) *Aggregator {
+	a := &Aggregator{
+		api:        pushAPIv3,
+		window:     50 * time.Millisecond,
+		maxTargets: 1000,
+		batches:    make(map[string]*aggregateBatch),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// 发送推送，满足合并条件时等待所在批次发送完毕后返回合并推送的结果。
+//   - ctx 仅控制等待的时长，ctx 被取消时立即返回，但所在批次仍会被发送；
+//   - 合并推送使用的是独立于各调用方的上下文。
+func (a *Aggregator) Send(ctx context.Context, param *SendParam) (*SendResult, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	targets, byAlias, ok := aggregateTargets(param)
+	if !ok || len(targets) > a.maxTargets {
+		return a.api.Send(ctx, param)
+	}
+	tmpl := *param
+	tmpl.Audience = nil
+	data, err := json.Marshal(&tmpl)
+	if err != nil {
+		return nil, err
+	}
+	// Class 不参与 JSON 序列化，但不同业务消息类别的推送发送时的厂商通道参数不同，不能合并。
+	key := string(param.Class) + "\x00" + string(data)
+	if byAlias {
+		key = "alias:" + key
+	} else {
+		key = "registration_id:" + key
+	}
+
+	ch := make(chan aggregateResult, 1)
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil, ErrAggregatorClosed
+	}
+	b := a.batches[key]
+	if b != nil && len(b.targets)+countNew(b, targets) > a.maxTargets {
+		a.detach(b)
+		a.flushAsync(b)
+		b = nil
+	}
+	if b == nil {
+		b = &aggregateBatch{key: key, param: &tmpl, byAlias: byAlias, seen: make(map[string]bool)}
+		b.timer = time.AfterFunc(a.window, func() { a.flushKey(b) })
+		a.batches[key] = b
+	}
+	for _, t := range targets {
+		if !b.seen[t] {
+			b.seen[t] = true
+			b.targets = append(b.targets, t)
+		}
+	}
+	b.waiters = append(b.waiters, ch)
+	if len(b.targets) >= a.maxTargets {
+		a.detach(b)
+		a.flushAsync(b)
+	}
+	a.mu.Unlock()
+
+	select {
+	case r := <-ch:
+		return r.result, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// 获取可合并的推送目标：Audience 只设置了 Registration ID 或别名之一，并且没有设置 CID。
+func aggregateTargets(param *SendParam) (targets []string, byAlias, ok bool) {
+	if param.CID != "" {
+		return nil, false, false
+	}
+	var aud *Audience
+	switch v := param.Audience.(type) {
+	case *Audience:
+		aud = v
+	case Audience:
+		aud = &v
+	}
+	if aud == nil || len(aud.Tags) > 0 || len(aud.AndTags) > 0 || len(aud.NotTags) > 0 ||
+		len(aud.Segments) > 0 || len(aud.AbTests) > 0 || aud.LiveActivityID != "" {
+		return nil, false, false
+	}
+	if len(aud.RegistrationIDs) > 0 && len(aud.Aliases) == 0 {
+		return aud.RegistrationIDs, false, true
+	}
+	if len(aud.Aliases) > 0 && len(aud.RegistrationIDs) == 0 {
+		return aud.Aliases, true, true
+	}
+	return nil, false, false
+}
+
+// 批次中尚不存在的推送目标数量，调用前需要持有锁。
+func countNew(b *aggregateBatch, targets []string) int {
+	n := 0
+	for _, t := range targets {
+		if !b.seen[t] {
+			n++
+		}
+	}
+	return n
+}
+
+// 将批次从等待中移除，使后续的推送进入新的批次，调用前需要持有锁。
+func (a *Aggregator) detach(b *aggregateBatch) {
+	if a.batches[b.key] == b {
+		delete(a.batches, b.key)
+	}
+	b.timer.Stop()
+	b.flushing = true
+}
+
+// 时间窗口到期时发送批次。
+func (a *Aggregator) flushKey(b *aggregateBatch) {
+	a.mu.Lock()
+	if b.flushing {
+		a.mu.Unlock()
+		return
+	}
+	a.detach(b)
+	a.wg.Add(1)
+	a.mu.Unlock()
+	defer a.wg.Done()
+	a.flush(b)
+}
+
+// 在后台发送已移除的批次，调用前需要持有锁。
+func (a *Aggregator) flushAsync(b *aggregateBatch) {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.flush(b)
+	}()
+}
+
+func (a *Aggregator) flush(b *aggregateBatch) {
+	param := *b.param
+	if b.byAlias {
+		param.Audience = &Audience{Aliases: b.targets}
+	} else {
+		param.Audience = &Audience{RegistrationIDs: b.targets}
+	}
+	result, err := a.api.Send(context.Background(), &param)
+	for _, ch := range b.waiters {
+		ch <- aggregateResult{result: result, err: err}
+	}
+}
+
+// 立即发送所有等待中的批次并等待其完成，关闭后 Send 返回 ErrAggregatorClosed（不满足合并条件的推送除外）。
+func (a *Aggregator) Close() {
+	a.mu.Lock()
+	a.closed = true
+	for _, b := range a.batches {
+		a.detach(b)
+		a.flushAsync(b)
+	}
+	a.mu.Unlock()
+	a.wg.Wait()
+}
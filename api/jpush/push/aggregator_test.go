@@ -0,0 +1,166 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+func paramTo(alert string, aud *push.Audience) *push.SendParam {
+	param := newParam(alert)
+	param.Audience = aud
+	return param
+}
+
+// 并发发送并返回每个调用的结果。
+func sendAll(t *testing.T, sender interface {
+	Send(ctx context.Context, param *push.SendParam) (*push.SendResult, error)
+}, params []*push.SendParam,
+) []*push.SendResult {
+	results := make([]*push.SendResult, len(params))
+	var wg sync.WaitGroup
+	for i, param := range params {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := sender.Send(context.Background(), param)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func TestAggregator(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, nil, androidDevice("rid1"), androidDevice("rid2"), androidDevice("rid3"))
+	a := push.NewAggregator(pushAPIv3, push.WithAggregateWindow(20*time.Millisecond))
+	defer a.Close()
+
+	results := sendAll(t, a, []*push.SendParam{
+		paramTo("hello", &push.Audience{RegistrationIDs: []string{"rid1"}}),
+		paramTo("hello", &push.Audience{RegistrationIDs: []string{"rid2"}}),
+		paramTo("hello", &push.Audience{RegistrationIDs: []string{"rid2", "rid3"}}),
+		paramTo("bye", &push.Audience{RegistrationIDs: []string{"rid1"}}),
+		paramTo("hello", &push.Audience{Aliases: []string{"alias-rid1"}}),
+	})
+
+	pushes := srv.Pushes()
+	if len(pushes) != 3 {
+		t.Fatalf("len(Pushes()) = %d, want 3", len(pushes))
+	}
+	for _, p := range pushes {
+		if p.MsgID == results[0].MsgID && len(p.Targets) != 3 {
+			t.Errorf("merged push targets = %v, want 3 targets", p.Targets)
+		}
+	}
+	if results[0].MsgID != results[1].MsgID || results[1].MsgID != results[2].MsgID {
+		t.Errorf("merged callers got different msg_id: %q, %q, %q", results[0].MsgID, results[1].MsgID, results[2].MsgID)
+	}
+	if results[3].MsgID == results[0].MsgID || results[4].MsgID == results[0].MsgID {
+		t.Error("different content or target type should not be merged")
+	}
+}
+
+func TestAggregatorMaxTargetsAndErrors(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, nil, androidDevice("rid1"), androidDevice("rid2"), androidDevice("rid3"))
+	a := push.NewAggregator(pushAPIv3, push.WithAggregateWindow(time.Hour), push.WithAggregateMaxTargets(2))
+
+	results := sendAll(t, a, []*push.SendParam{
+		paramTo("hello", &push.Audience{RegistrationIDs: []string{"rid1"}}),
+		paramTo("hello", &push.Audience{RegistrationIDs: []string{"rid2"}}),
+	})
+	if results[0].MsgID == "" || results[0].MsgID != results[1].MsgID {
+		t.Errorf("full batch should be sent immediately, got %q and %q", results[0].MsgID, results[1].MsgID)
+	}
+
+	results = sendAll(t, a, []*push.SendParam{
+		paramTo("hello", &push.Audience{Aliases: []string{"nobody"}}),
+		paramTo("hello", &push.Audience{Aliases: []string{"nobody-else"}}),
+	})
+	for _, result := range results {
+		if result.Error == nil || result.Error.Code != 1011 {
+			t.Errorf("result = %+v, want error 1011 for every caller", result.Error)
+		}
+	}
+
+	// 不满足合并条件的推送直接发送
+	if result, err := a.Send(context.Background(), paramTo("hello", &push.Audience{Tags: []string{"vip"}})); err != nil || result.Error.Code != 1011 {
+		t.Errorf("Send() by tag = %+v, %v", result, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = a.Send(context.Background(), paramTo("pending", &push.Audience{RegistrationIDs: []string{"rid3"}}))
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	a.Close() // 立即发送等待中的批次
+	<-done
+	if n := len(srv.Pushes()); n != 2 {
+		t.Errorf("len(Pushes()) = %d after Close, want 2", n)
+	}
+	if _, err := a.Send(context.Background(), paramTo("closed", &push.Audience{RegistrationIDs: []string{"rid1"}})); err != push.ErrAggregatorClosed {
+		t.Errorf("Send() after Close = %v, want ErrAggregatorClosed", err)
+	}
+}
+
+func TestAggregator_Class(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, nil, androidDevice("rid1"), androidDevice("rid2"), androidDevice("rid3"))
+	a := push.NewAggregator(pushAPIv3, push.WithAggregateWindow(20*time.Millisecond))
+	defer a.Close()
+
+	classes := []push.MessageClass{push.ClassTransactional, push.ClassMarketing, push.ClassTransactional}
+	params := make([]*push.SendParam, len(classes))
+	for i, class := range classes {
+		params[i] = paramTo("hello", &push.Audience{RegistrationIDs: []string{fmt.Sprintf("rid%d", i+1)}})
+		params[i].Class = class
+	}
+	results := sendAll(t, a, params)
+	if results[0].MsgID != results[2].MsgID || results[0].MsgID == results[1].MsgID {
+		t.Errorf("only the same class should be merged, got msg_id %q, %q, %q", results[0].MsgID, results[1].MsgID, results[2].MsgID)
+	}
+
+	reqs := srv.RequestsTo(http.MethodPost, "/v3/push")
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 push requests, got %d", len(reqs))
+	}
+	for _, req := range reqs {
+		var body struct {
+			Audience push.Audience `json:"audience"`
+			Options  push.Options  `json:"options"`
+		}
+		if err := req.Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		want := options.ClassificationSystem
+		if len(body.Audience.RegistrationIDs) == 1 {
+			want = options.ClassificationMarketing
+		}
+		if c := body.Options.Classification; c == nil || *c != int(want) {
+			t.Errorf("audience %v: expected classification %d, got %v", body.Audience.RegistrationIDs, want, c)
+		}
+	}
+}
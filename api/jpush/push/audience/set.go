@@ -0,0 +1,317 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audience
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// 创建按注册 ID 推送的设备对象，重复值和空值会被去除。
+func RegistrationIDs(registrationIDs ...string) *Audience {
+	return &Audience{RegistrationIDs: dedup(registrationIDs)}
+}
+
+// 创建按标签推送的设备对象，多个标签之间是 OR 的关系，重复值和空值会被去除。
+func Tags(tags ...string) *Audience {
+	return &Audience{Tags: dedup(tags)}
+}
+
+// 创建按标签推送的设备对象，多个标签之间是 AND 的关系，重复值和空值会被去除。
+func AndTags(tags ...string) *Audience {
+	return &Audience{AndTags: dedup(tags)}
+}
+
+// 创建按标签排除的设备对象，推送给不属于任一标签的设备，重复值和空值会被去除。
+func NotTags(tags ...string) *Audience {
+	return &Audience{NotTags: dedup(tags)}
+}
+
+// 创建按别名推送的设备对象，重复值和空值会被去除。
+func Aliases(aliases ...string) *Audience {
+	return &Audience{Aliases: dedup(aliases)}
+}
+
+// 创建按用户分群推送的设备对象。
+func Segment(segmentID string) *Audience {
+	return &Audience{Segments: dedup([]string{segmentID})}
+}
+
+// 创建按 A/B 测试推送的设备对象。
+func AbTest(abTestID string) *Audience {
+	return &Audience{AbTests: dedup([]string{abTestID})}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 返回推送设备对象的深拷贝。
+func (a *Audience) Clone() *Audience {
+	if a == nil {
+		return nil
+	}
+	c := *a
+	c.RegistrationIDs = slices.Clone(a.RegistrationIDs)
+	c.Tags = slices.Clone(a.Tags)
+	c.AndTags = slices.Clone(a.AndTags)
+	c.NotTags = slices.Clone(a.NotTags)
+	c.Aliases = slices.Clone(a.Aliases)
+	c.Segments = slices.Clone(a.Segments)
+	c.AbTests = slices.Clone(a.AbTests)
+	if a.File != nil {
+		f := *a.File
+		c.File = &f
+	}
+	return &c
+}
+
+// 返回去除了各类推送目标中重复值和空值的副本，保留值的原有顺序。
+func (a *Audience) Dedup() *Audience {
+	if a == nil {
+		return nil
+	}
+	c := a.Clone()
+	c.RegistrationIDs = dedup(c.RegistrationIDs)
+	c.Tags = dedup(c.Tags)
+	c.AndTags = dedup(c.AndTags)
+	c.NotTags = dedup(c.NotTags)
+	c.Aliases = dedup(c.Aliases)
+	c.Segments = dedup(c.Segments)
+	c.AbTests = dedup(c.AbTests)
+	return c
+}
+
+// 返回从当前推送设备对象中排除了指定标签（tag_not）的副本。
+func (a *Audience) Except(tags ...string) *Audience {
+	c := a.Clone()
+	if c == nil {
+		c = &Audience{}
+	}
+	c.NotTags = dedup(append(c.NotTags, tags...))
+	return c
+}
+
+// 求多个推送设备对象的交集，即推送给同时满足所有推送设备对象的设备。
+//
+// JPush 中同一推送设备对象的不同类型推送目标之间是 AND 的关系，因此交集按照以下规则合并：
+//   - RegistrationIDs、Aliases：取值的交集，交集为空时返回错误；
+//   - AndTags、NotTags：取值的并集；
+//   - Tags（OR 关系）：只有一方指定时直接使用；多方指定时，相同的标签集合保持不变，只包含一个标签的集合合并到 AndTags，否则无法表示，返回错误；
+//   - Segments、AbTests：只能有一个取值，多方指定不同的值时返回错误；
+//   - LiveActivityID、File：不能与其他推送设备对象求交集，返回错误。
+func Intersect(auds ...*Audience) (*Audience, error) {
+	var result *Audience
+	for i, a := range auds {
+		if a.IsEmpty() {
+			continue
+		}
+		if result == nil {
+			result = a.Dedup()
+			continue
+		}
+		if result.LiveActivityID != "" || result.File != nil || a.LiveActivityID != "" || a.File != nil {
+			return nil, errors.New("live_activity_id or file audience cannot be intersected with other audiences")
+		}
+
+		b := a.Dedup()
+		var err error
+		if result.RegistrationIDs, err = intersectValues("registration_id", result.RegistrationIDs, b.RegistrationIDs); err != nil {
+			return nil, err
+		}
+		if result.Aliases, err = intersectValues("alias", result.Aliases, b.Aliases); err != nil {
+			return nil, err
+		}
+		result.AndTags = dedup(append(result.AndTags, b.AndTags...))
+		result.NotTags = dedup(append(result.NotTags, b.NotTags...))
+		switch {
+		case len(b.Tags) == 0 || sameSet(result.Tags, b.Tags):
+		case len(result.Tags) == 0:
+			result.Tags = b.Tags
+		case len(b.Tags) == 1:
+			result.AndTags = dedup(append(result.AndTags, b.Tags...))
+		case len(result.Tags) == 1:
+			result.AndTags = dedup(append(result.AndTags, result.Tags...))
+			result.Tags = b.Tags
+		default:
+			return nil, fmt.Errorf("cannot intersect tag sets %v and %v (audience #%d)", result.Tags, b.Tags, i)
+		}
+		if result.Segments, err = intersectSingle("segment", result.Segments, b.Segments); err != nil {
+			return nil, err
+		}
+		if result.AbTests, err = intersectSingle("abtest", result.AbTests, b.AbTests); err != nil {
+			return nil, err
+		}
+	}
+	if result == nil {
+		return nil, errors.New("no audience to intersect")
+	}
+	return result, nil
+}
+
+// 求多个推送设备对象的并集，即推送给满足任一推送设备对象的设备。
+//
+// JPush 中只有同一类型的 RegistrationIDs、Tags 或 Aliases 内部是 OR 的关系，因此要求所有推送设备对象都只指定了其中同一种类型，
+// 合并后的值会去除重复值，否则返回错误。合并后数量超过限制时，可以通过 Split 拆分 RegistrationIDs 和 Aliases。
+func Union(auds ...*Audience) (*Audience, error) {
+	var result *Audience
+	var kind string
+	for i, a := range auds {
+		if a.IsEmpty() {
+			continue
+		}
+		k := unionKind(a)
+		if k == "" {
+			return nil, fmt.Errorf("audience #%d must only contain one of registration_id, tag or alias to be united", i)
+		}
+		if result == nil {
+			result, kind = a.Dedup(), k
+			continue
+		}
+		if k != kind {
+			return nil, fmt.Errorf("cannot unite %s audience with %s audience (audience #%d)", kind, k, i)
+		}
+		result.RegistrationIDs = dedup(append(result.RegistrationIDs, a.RegistrationIDs...))
+		result.Tags = dedup(append(result.Tags, a.Tags...))
+		result.Aliases = dedup(append(result.Aliases, a.Aliases...))
+	}
+	if result == nil {
+		return nil, errors.New("no audience to unite")
+	}
+	return result, nil
+}
+
+// 将注册 ID 或别名数量超过单次推送限制的推送设备对象拆分为多个符合限制的推送设备对象，以便分别推送。
+//   - 拆分前会先去除重复值和空值，其他类型的推送目标会原样复制到每个拆分结果中；
+//   - 同时指定了 RegistrationIDs 和 Aliases 时（两者是 AND 的关系），按两者分块的笛卡尔积拆分；
+//   - 拆分结果仍然无法通过 Validate 校验时（如标签数量超过限制），返回校验错误。
+func (a *Audience) Split() ([]*Audience, error) {
+	if a == nil {
+		return nil, a.Validate()
+	}
+	d := a.Dedup()
+	ridChunks := chunkValues(d.RegistrationIDs, MaxRegistrationIDs)
+	aliasChunks := chunkValues(d.Aliases, MaxAliases)
+
+	auds := make([]*Audience, 0, len(ridChunks)*len(aliasChunks))
+	for _, rids := range ridChunks {
+		for _, aliases := range aliasChunks {
+			c := d.Clone()
+			c.RegistrationIDs, c.Aliases = rids, aliases
+			if err := c.Validate(); err != nil {
+				return nil, err
+			}
+			auds = append(auds, c)
+		}
+	}
+	return auds, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 去除重复值和空值，保留值的原有顺序，结果为空时返回 nil。
+func dedup(values []string) []string {
+	var result []string
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, v := range a {
+		set[v] = true
+	}
+	for _, v := range b {
+		if !set[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// 求两个 OR 关系取值列表的交集，任一方为空时直接使用另一方。
+func intersectValues(name string, a, b []string) ([]string, error) {
+	if len(a) == 0 {
+		return b, nil
+	}
+	if len(b) == 0 {
+		return a, nil
+	}
+	set := make(map[string]bool, len(b))
+	for _, v := range b {
+		set[v] = true
+	}
+	var result []string
+	for _, v := range a {
+		if set[v] {
+			result = append(result, v)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("intersection of %s is empty", name)
+	}
+	return result, nil
+}
+
+// 合并两个只能有一个取值的列表（如用户分群），双方取值不同时返回错误。
+func intersectSingle(name string, a, b []string) ([]string, error) {
+	if len(a) == 0 {
+		return b, nil
+	}
+	if len(b) == 0 || sameSet(a, b) {
+		return a, nil
+	}
+	return nil, fmt.Errorf("cannot intersect different %s %v and %v", name, a, b)
+}
+
+// 返回只指定了 RegistrationIDs、Tags 或 Aliases 其中之一的推送设备对象的类型，否则返回空字符串。
+func unionKind(a *Audience) string {
+	if len(a.AndTags) > 0 || len(a.NotTags) > 0 || len(a.Segments) > 0 || len(a.AbTests) > 0 || a.LiveActivityID != "" || a.File != nil {
+		return ""
+	}
+	var kind string
+	for name, values := range map[string][]string{"registration_id": a.RegistrationIDs, "tag": a.Tags, "alias": a.Aliases} {
+		if len(values) > 0 {
+			if kind != "" {
+				return ""
+			}
+			kind = name
+		}
+	}
+	return kind
+}
+
+// 按照 `size` 分块，列表为空时返回只包含 nil 的分块，以便与其他分块求笛卡尔积。
+func chunkValues(values []string, size int) [][]string {
+	if len(values) == 0 {
+		return [][]string{nil}
+	}
+	chunks := api.Chunk(values, size)
+	for i, chunk := range chunks {
+		chunks[i] = slices.Clone(chunk) // 避免分块之间共享底层数组
+	}
+	return chunks
+}
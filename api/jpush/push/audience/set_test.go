@@ -0,0 +1,155 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audience_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/audience"
+)
+
+func TestValidate(t *testing.T) {
+	if err := audience.Tags("vip", "北京", "a_b@c.d", "￥100").Validate(); err != nil {
+		t.Fatalf("expected a valid audience, got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		aud   *audience.Audience
+		field string
+	}{
+		{"empty", &audience.Audience{}, ""},
+		{"too many rids", &audience.Audience{RegistrationIDs: ids("rid", 1001)}, "registration_id"},
+		{"empty tag", &audience.Audience{Tags: []string{"vip", ""}}, "tag[1]"},
+		{"invalid character", &audience.Audience{Aliases: []string{"bad alias"}}, "alias[0]"},
+		{"too long", &audience.Audience{AndTags: []string{strings.Repeat("标", 14)}}, "tag_and[0]"},
+		{"two segments", &audience.Audience{Segments: []string{"s1", "s2"}}, "segment"},
+		{"mixed file", &audience.Audience{Tags: []string{"vip"}, File: &audience.File{FileID: "f"}}, "file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs api.ValidationErrors
+			if !errors.As(tt.aud.Validate(), &errs) {
+				t.Fatalf("expected ValidationErrors")
+			}
+			for _, e := range errs {
+				if e.Field == tt.field {
+					return
+				}
+			}
+			t.Errorf("expected an error on %q, got %v", tt.field, errs)
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	aud, err := audience.Intersect(
+		audience.Tags("beijing", "shanghai"),
+		audience.Tags("vip"),
+		audience.RegistrationIDs("r1", "r2", "r3"),
+		audience.RegistrationIDs("r2", "r3", "r4"),
+		audience.Tags("vip").Except("blocked", "blocked"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &audience.Audience{
+		RegistrationIDs: []string{"r2", "r3"},
+		Tags:            []string{"beijing", "shanghai"},
+		AndTags:         []string{"vip"},
+		NotTags:         []string{"blocked"},
+	}
+	if !reflect.DeepEqual(aud, want) {
+		t.Errorf("got %+v, want %+v", aud, want)
+	}
+
+	for name, auds := range map[string][]*audience.Audience{
+		"two tag sets":    {audience.Tags("a", "b"), audience.Tags("c", "d")},
+		"disjoint rids":   {audience.RegistrationIDs("r1"), audience.RegistrationIDs("r2")},
+		"two segments":    {audience.Segment("s1"), audience.Segment("s2")},
+		"live activity":   {audience.Tags("a"), {LiveActivityID: "la"}},
+		"nothing to join": {nil, {}},
+	} {
+		if _, err := audience.Intersect(auds...); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	aud, err := audience.Union(audience.Aliases("a", "b"), nil, audience.Aliases("b", "c", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(aud.Aliases, want) {
+		t.Errorf("got %v, want %v", aud.Aliases, want)
+	}
+
+	if _, err = audience.Union(audience.Aliases("a"), audience.Tags("t")); err == nil {
+		t.Error("expected an error when uniting different kinds")
+	}
+	if _, err = audience.Union(audience.Tags("t").Except("x")); err == nil {
+		t.Error("expected an error when uniting tag_not")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	rids := append(ids("rid", 2500), "rid0", "")
+	auds, err := (&audience.Audience{RegistrationIDs: rids, Tags: []string{"vip"}}).Split()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auds) != 3 {
+		t.Fatalf("expected 3 audiences, got %d", len(auds))
+	}
+	total := 0
+	for _, aud := range auds {
+		if err = aud.Validate(); err != nil {
+			t.Errorf("split audience is invalid: %v", err)
+		}
+		if !reflect.DeepEqual(aud.Tags, []string{"vip"}) {
+			t.Errorf("expected tags to be kept, got %v", aud.Tags)
+		}
+		total += len(aud.RegistrationIDs)
+	}
+	if total != 2500 {
+		t.Errorf("expected 2500 registration IDs in total, got %d", total)
+	}
+
+	auds, err = (&audience.Audience{RegistrationIDs: ids("rid", 1500), Aliases: ids("alias", 1200)}).Split()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auds) != 4 {
+		t.Errorf("expected 2x2 audiences, got %d", len(auds))
+	}
+
+	if _, err = (&audience.Audience{Aliases: ids("alias", 10), Tags: ids("tag", 21)}).Split(); err == nil {
+		t.Error("expected an error when tags exceed the limit")
+	}
+}
+
+func ids(prefix string, n int) []string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return values
+}
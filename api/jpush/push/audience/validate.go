@@ -0,0 +1,119 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audience
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// 推送设备对象中各类推送目标的数量和长度限制，详见 [docs.jiguang.cn] 文档说明。
+//
+// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_push#audience%EF%BC%9A%E6%8E%A8%E9%80%81%E7%9B%AE%E6%A0%87
+const (
+	MaxRegistrationIDs = 1000 // 一次推送最多 1000 个注册 ID
+	MaxTags            = 20   // 一次推送最多 20 个标签（Tags、AndTags、NotTags 分别计算）
+	MaxAliases         = 1000 // 一次推送最多 1000 个别名
+	MaxSegments        = 1    // 一次推送最多 1 个用户分群
+	MaxAbTests         = 1    // 一次推送最多 1 个 A/B 测试
+	MaxTagAliasBytes   = 40   // 每一个标签或别名的长度限制为 40 字节（UTF-8 编码）
+)
+
+// 在本地按照 JPush 文档中的约束校验推送设备对象，无需发起网络请求。
+//
+// 校验通过时返回 nil，否则返回包含所有字段错误的 api.ValidationErrors，字段路径与 JSON 字段名一致，如 "tag[0]"，推送设备对象整体的错误字段路径为空，主要检查：
+//   - 至少指定一种推送目标，各类推送目标的数量不超过限制，且不包含空值；
+//   - 标签和别名的长度不超过 40 字节，且只能由字母、数字、下划线、汉字和特殊字符 @!#$&*+=.|￥ 组成；
+//   - LiveActivityID、File 不能与其他推送目标组合使用。
+func (a *Audience) Validate() error {
+	if a == nil {
+		return api.ValidationErrors{{Message: "audience cannot be nil"}}
+	}
+
+	var errs api.ValidationErrors
+	var kinds []string
+	check := func(name string, values []string, limit int, tagOrAlias bool) {
+		if len(values) == 0 {
+			return
+		}
+		kinds = append(kinds, name)
+		if len(values) > limit {
+			errs.Add(name, "cannot be more than %d, got %d", limit, len(values))
+		}
+		for i, s := range values {
+			field := fmt.Sprintf("%s[%d]", name, i)
+			if s == "" {
+				errs.Add(field, "cannot be empty")
+			} else if tagOrAlias {
+				if len(s) > MaxTagAliasBytes {
+					errs.Add(field, "cannot be longer than %d bytes", MaxTagAliasBytes)
+				}
+				if r, ok := invalidTagAliasRune(s); ok {
+					errs.Add(field, "contains invalid character %q", r)
+				}
+			}
+		}
+	}
+	check("registration_id", a.RegistrationIDs, MaxRegistrationIDs, false)
+	check("tag", a.Tags, MaxTags, true)
+	check("tag_and", a.AndTags, MaxTags, true)
+	check("tag_not", a.NotTags, MaxTags, true)
+	check("alias", a.Aliases, MaxAliases, true)
+	check("segment", a.Segments, MaxSegments, false)
+	check("abtest", a.AbTests, MaxAbTests, false)
+
+	exclusive := func(name string) {
+		if len(kinds) > 0 {
+			errs.Add(name, "cannot be combined with %s", strings.Join(kinds, ", "))
+		}
+		kinds = append(kinds, name)
+	}
+	if a.LiveActivityID != "" {
+		exclusive("live_activity_id")
+	}
+	if a.File != nil {
+		exclusive("file")
+		if a.File.FileID == "" {
+			errs.Add("file.file_id", "is required")
+		}
+	}
+	if len(kinds) == 0 {
+		errs.Add("", "must specify at least one target")
+	}
+	return errs.Err()
+}
+
+// 判断推送设备对象是否未指定任何推送目标。
+func (a *Audience) IsEmpty() bool {
+	return a == nil || len(a.RegistrationIDs) == 0 && len(a.Tags) == 0 && len(a.AndTags) == 0 && len(a.NotTags) == 0 &&
+		len(a.Aliases) == 0 && len(a.Segments) == 0 && len(a.AbTests) == 0 && a.LiveActivityID == "" && a.File == nil
+}
+
+// 检查标签或别名是否只由字母、数字、下划线、汉字和特殊字符 @!#$&*+=.|￥ 组成，返回第一个无效字符。
+func invalidTagAliasRune(s string) (rune, bool) {
+	for _, r := range s {
+		switch {
+		case r < unicode.MaxASCII && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'):
+		case r == '_' || strings.ContainsRune("@!#$&*+=.|￥", r):
+		case unicode.Is(unicode.Han, r):
+		default:
+			return r, true
+		}
+	}
+	return 0, false
+}
@@ -14,7 +14,12 @@
 
 package cid
 
-import "github.com/cavlabs/jiguang-sdk-go/api"
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
 
 // # 获取推送/定时推送唯一标识 (CID) 结果
 type GetResult struct {
@@ -26,3 +31,26 @@ type GetResult struct {
 func (rs *GetResult) IsSuccess() bool {
 	return rs != nil && rs.StatusCode/100 == 2 && rs.Error.IsSuccess()
 }
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 判断推送/定时推送参数是否携带了 CID，服务端会对携带相同 CID 的请求去重，因而此类请求可安全重试。
+//   - 结构体（或其指针）形式的参数读取 CID 字段，map[string]interface{} 和 json.RawMessage 形式的参数读取 "cid" 字段。
+func Carried(param interface{}) bool {
+	switch p := param.(type) {
+	case map[string]interface{}:
+		cid, _ := p["cid"].(string)
+		return cid != ""
+	case json.RawMessage:
+		var aux struct {
+			CID string `json:"cid"`
+		}
+		return json.Unmarshal(p, &aux) == nil && aux.CID != ""
+	}
+	v := reflect.Indirect(reflect.ValueOf(param))
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	cid := v.FieldByName("CID")
+	return cid.Kind() == reflect.String && cid.String() != ""
+}
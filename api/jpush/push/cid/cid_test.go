@@ -0,0 +1,46 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/cid"
+)
+
+func TestCarried(t *testing.T) {
+	type param struct {
+		CID string `json:"cid,omitempty"`
+	}
+	tests := []struct {
+		name  string
+		param interface{}
+		want  bool
+	}{
+		{"struct pointer", &param{CID: "8103a4c6-1d4d"}, true},
+		{"struct without cid", &param{}, false},
+		{"nil struct pointer", (*param)(nil), false},
+		{"map", map[string]interface{}{"cid": "8103a4c6-1d4d"}, true},
+		{"raw json", json.RawMessage(`{"cid":"8103a4c6-1d4d"}`), true},
+		{"raw json without cid", json.RawMessage(`{"platform":"all"}`), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := cid.Carried(tt.param); got != tt.want {
+			t.Errorf("%s: Carried() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
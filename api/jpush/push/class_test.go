@@ -0,0 +1,129 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+func TestApplyClass(t *testing.T) {
+	param := newParam("order shipped")
+	param.Class = push.ClassTransactional
+	param.Notification.Android = &push.AndroidNotification{Alert: "order shipped", Category: "CUSTOM"}
+	param.Notification.HMOS = &push.HmosNotification{Alert: "order shipped"}
+	param.Options = &push.Options{ThirdPartyChannel: &push.ThirdPartyChannel{
+		Xiaomi: options.NewXiaomiOptions("order"),
+		Huawei: &push.ThirdPartyChannelOptions{Importance: string(options.HuaweiImportanceHigh)},
+	}}
+
+	applied, err := param.ApplyClass(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = applied.Validate(); err != nil {
+		t.Fatalf("expected the applied param to be valid, got %v", err)
+	}
+
+	o := applied.Options
+	if *o.Classification != int(options.ClassificationSystem) {
+		t.Errorf("expected system classification, got %d", *o.Classification)
+	}
+	huawei := o.ThirdPartyChannel.Huawei
+	if huawei.Category != string(options.HuaweiCategoryExpress) || huawei.Importance != string(options.HuaweiImportanceHigh) {
+		t.Errorf("expected explicit importance to be kept, got %+v", huawei)
+	}
+	if o.ThirdPartyChannel.Xiaomi.ChannelID != "order" || o.ThirdPartyChannel.Vivo.Category != string(options.VivoCategoryOrder) {
+		t.Errorf("unexpected third party channel %+v", o.ThirdPartyChannel)
+	}
+	if applied.Notification.Android.Category != "CUSTOM" || applied.Notification.HMOS.Category != "EXPRESS" {
+		t.Errorf("unexpected notification categories %+v, %+v", applied.Notification.Android, applied.Notification.HMOS)
+	}
+
+	// 原推送参数保持不变。
+	if param.Options.Classification != nil || param.Options.ThirdPartyChannel.Huawei.Category != "" || param.Notification.HMOS.Category != "" {
+		t.Error("expected the original param to be unchanged")
+	}
+
+	param.Class = "unknown"
+	if _, err = param.ApplyClass(nil); err == nil {
+		t.Error("expected an error for unknown class")
+	}
+}
+
+func TestSend_ClassPolicy(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, func(cfg *sdk.Config) {
+		cfg.ValidateBeforeSend = true
+		cfg.ClassPolicy = options.DefaultClassPolicy().With(push.ClassPolicy{
+			push.ClassSocial: {
+				Classification:    options.ClassificationSystem,
+				ThirdPartyChannel: push.ThirdPartyChannel{OPPO: options.NewOPPOOptions("chat", options.OPPOCategoryIM, options.OPPONotifyLevelAllAlerts)},
+				AndroidChannelID:  "chat",
+			},
+		})
+	}, androidDevice("rid1"))
+
+	param, err := push.New().Broadcast().Android(&push.AndroidNotification{Alert: "new message"}).Class(push.ClassSocial).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := pushAPIv3.Send(context.Background(), param); err != nil || !result.IsSuccess() {
+		t.Fatalf("Send() = %+v, %v", result, err)
+	}
+
+	reqs := srv.RequestsTo("POST", "/v3/push")
+	if len(reqs) != 1 {
+		t.Fatalf("expected 1 push request, got %d", len(reqs))
+	}
+	var body push.SendParam
+	if err = reqs[0].Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	oppo := body.Options.ThirdPartyChannel.OPPO
+	if oppo == nil || oppo.ChannelID != "chat" || oppo.NotifyLevel != int(options.OPPONotifyLevelAllAlerts) {
+		t.Errorf("expected the overridden OPPO options, got %+v", oppo)
+	}
+	if body.Options.ThirdPartyChannel.Huawei != nil {
+		t.Errorf("expected no huawei options from the overridden profile, got %+v", body.Options.ThirdPartyChannel.Huawei)
+	}
+	if body.Notification.Android.ChannelID != "chat" {
+		t.Errorf("expected android channel id to be applied, got %q", body.Notification.Android.ChannelID)
+	}
+}
+
+func TestValidateSend_Class(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, nil, androidDevice("rid1"))
+
+	param := newParam("flash sale")
+	param.Class = push.ClassMarketing
+	if result, err := pushAPIv3.ValidateSend(context.Background(), param); err != nil || !result.IsSuccess() {
+		t.Fatalf("ValidateSend() = %+v, %v", result, err)
+	}
+	reqs := srv.RequestsTo("POST", "/v3/push/validate")
+	if len(reqs) != 1 {
+		t.Fatalf("expected 1 validate request, got %d", len(reqs))
+	}
+	var body push.SendParam
+	if err := reqs[0].Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Options == nil || body.Options.Classification == nil || *body.Options.Classification != int(options.ClassificationMarketing) {
+		t.Errorf("expected the marketing classification, got %+v", body.Options)
+	}
+}
@@ -0,0 +1,437 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+var (
+	// 推送分发器已关闭，不再接受新的推送任务，或者推送任务在关闭前未能被发送。
+	ErrDispatcherClosed = errors.New("push dispatcher is closed")
+	// 推送分发器的队列已满，TrySubmit 无法立即提交推送任务。
+	ErrQueueFull = errors.New("push dispatcher queue is full")
+)
+
+// 推送任务的优先级，数值越大越先被发送，相同优先级的推送任务按提交的顺序发送。
+type Priority int
+
+const (
+	PriorityLow    Priority = -1 // 低优先级，如营销推送
+	PriorityNormal Priority = 0  // 普通优先级（默认）
+	PriorityHigh   Priority = 1  // 高优先级，如验证码、交易提醒等
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 异步推送分发器
+//
+// 将推送任务放入有界的优先级队列，由固定数量的 worker 通过 APIv3.Send 异步发送，使调用方的延迟与极光推送服务解耦：
+//   - 队列已满时，Submit 阻塞等待（背压），TrySubmit 立即返回 ErrQueueFull；
+//   - 每个推送任务可以设置优先级、截止时间，以及通过回调或 Future 获取推送结果；
+//   - 当推送结果的 `X-Rate-Limit-*` 响应头表明当前时间窗口的可用次数已耗尽（或返回 429）时，所有 worker 暂停发送，直到时间窗口重置；
+//   - 调用 Close 优雅关闭：停止接受新的推送任务，并等待队列中的推送任务发送完毕，超时后未发送的推送任务交给 PendingHandler 处理。
+//
+// 可被多个 goroutine 并发使用。
+type Dispatcher struct {
+	api            APIv3
+	workers        int
+	slots          chan struct{} // 队列容量的信号量
+	resultHandler  func(param *SendParam, result *SendResult, err error)
+	pendingHandler func(params []*SendParam) error
+
+	ctx    context.Context // 所有推送任务的基础上下文，在 Close 超时时被取消
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu          sync.Mutex
+	cond        *sync.Cond
+	queue       jobQueue
+	seq         uint64
+	closed      bool
+	pausedUntil time.Time // 在此时间之前暂停发送，以遵守 API 频率控制
+}
+
+// 用于配置 Dispatcher 的选项。
+type DispatcherOption func(*Dispatcher)
+
+// 设置 worker 的数量，即同时发送推送请求的最大数量，默认为 4。
+func WithDispatcherWorkers(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.workers = n
+		}
+	}
+}
+
+// 设置队列的容量，即等待发送的推送任务的最大数量，默认为 1000。
+func WithDispatcherQueueSize(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// 设置所有推送任务完成时（包括发送失败、超时和因关闭而取消）都会被调用的结果处理函数，如用于记录日志或指标。
+//   - 该函数在 worker 中同步调用，不应长时间阻塞。
+func WithDispatcherResultHandler(handler func(param *SendParam, result *SendResult, err error)) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.resultHandler = handler
+	}
+}
+
+// 设置 Close 超时时对队列中尚未发送的推送任务的处理函数，如将其持久化以便在下次启动时重新提交。
+//   - 未设置时，这些推送任务直接以 ErrDispatcherClosed 失败；
+//   - 无论是否设置，这些推送任务的 Future 都会以 ErrDispatcherClosed 完成。
+func WithDispatcherPendingHandler(handler func(params []*SendParam) error) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.pendingHandler = handler
+	}
+}
+
+// 创建并启动异步推送分发器，使用完毕后需要调用 Close 关闭。
+func NewDispatcher(pushAPIv3 APIv3, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{api: pushAPIv3, workers: 4}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.slots == nil {
+		d.slots = make(chan struct{}, 1000)
+	}
+	d.cond = sync.NewCond(&d.mu)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+
+	d.wg.Add(d.workers)
+	for i := 0; i < d.workers; i++ {
+		go d.work()
+	}
+	return d
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 推送任务的配置选项。
+type JobOption func(*job)
+
+// 设置推送任务的优先级，默认为 PriorityNormal。
+func WithJobPriority(p Priority) JobOption {
+	return func(j *job) {
+		j.priority = p
+	}
+}
+
+// 设置推送任务的截止时间，在此之前未能发送成功（包括排队等待的时间）的推送任务以 context.DeadlineExceeded 失败。
+func WithJobDeadline(deadline time.Time) JobOption {
+	return func(j *job) {
+		j.deadline = deadline
+	}
+}
+
+// 设置推送任务从提交开始计算的超时时长，等同于 WithJobDeadline(time.Now().Add(timeout))。
+func WithJobTimeout(timeout time.Duration) JobOption {
+	return func(j *job) {
+		j.deadline = time.Now().Add(timeout)
+	}
+}
+
+// 设置推送任务完成时的回调函数，在 worker 中同步调用，不应长时间阻塞。
+func WithJobCallback(callback func(result *SendResult, err error)) JobOption {
+	return func(j *job) {
+		j.callback = callback
+	}
+}
+
+type job struct {
+	param    *SendParam
+	priority Priority
+	deadline time.Time
+	callback func(result *SendResult, err error)
+	future   *Future
+	seq      uint64
+}
+
+// Future 是异步推送任务的结果。
+type Future struct {
+	done   chan struct{}
+	result *SendResult
+	err    error
+}
+
+// 推送任务完成时被关闭的通道。
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// 等待推送任务完成并返回推送结果，ctx 仅控制等待的时长，不会取消推送任务。
+func (f *Future) Wait(ctx context.Context) (*SendResult, error) {
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 提交推送任务，队列已满时阻塞等待，直到队列有空位、ctx 被取消或分发器被关闭。
+//   - ctx 仅控制提交时的等待，推送任务的截止时间请使用 WithJobDeadline 或 WithJobTimeout 设置。
+func (d *Dispatcher) Submit(ctx context.Context, param *SendParam, opts ...JobOption) (*Future, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	select {
+	case d.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-d.ctx.Done():
+		return nil, ErrDispatcherClosed
+	}
+	return d.enqueue(param, opts)
+}
+
+// 尝试提交推送任务，队列已满时立即返回 ErrQueueFull。
+func (d *Dispatcher) TrySubmit(param *SendParam, opts ...JobOption) (*Future, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	select {
+	case d.slots <- struct{}{}:
+	default:
+		return nil, ErrQueueFull
+	}
+	return d.enqueue(param, opts)
+}
+
+// 在已获取队列空位后，将推送任务放入队列。
+func (d *Dispatcher) enqueue(param *SendParam, opts []JobOption) (*Future, error) {
+	j := &job{param: param, future: &Future{done: make(chan struct{})}}
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		<-d.slots
+		return nil, ErrDispatcherClosed
+	}
+	d.seq++
+	j.seq = d.seq
+	heap.Push(&d.queue, j)
+	d.cond.Signal()
+	return j.future, nil
+}
+
+// 获取队列中等待发送的推送任务数量。
+func (d *Dispatcher) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queue.Len()
+}
+
+// 优雅关闭分发器：停止接受新的推送任务，等待队列中的推送任务全部发送完毕。
+//
+// 如果 ctx 在此之前被取消，则：
+//   - 队列中尚未发送的推送任务交给 PendingHandler 处理（如已设置），其 Future 以 ErrDispatcherClosed 完成；
+//   - 正在发送的推送请求被取消；
+//   - 返回 ctx 的错误（以及 PendingHandler 返回的错误）。
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return ErrDispatcherClosed
+	}
+	d.closed = true
+	d.cond.Broadcast()
+	d.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		d.cancel()
+		return nil
+	case <-ctx.Done():
+	}
+
+	d.mu.Lock()
+	pending := make([]*job, 0, d.queue.Len())
+	for d.queue.Len() > 0 {
+		pending = append(pending, heap.Pop(&d.queue).(*job))
+	}
+	d.mu.Unlock()
+	d.cancel()
+
+	var err error
+	if len(pending) > 0 && d.pendingHandler != nil {
+		params := make([]*SendParam, len(pending))
+		for i, j := range pending {
+			params[i] = j.param
+		}
+		err = d.pendingHandler(params)
+	}
+	for _, j := range pending {
+		<-d.slots
+		d.complete(j, nil, ErrDispatcherClosed)
+	}
+	<-drained
+	return errors.Join(ctx.Err(), err)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for {
+		j, ok := d.next()
+		if !ok {
+			return
+		}
+		d.send(j)
+	}
+}
+
+// 获取下一个推送任务，队列为空时阻塞等待，分发器已关闭且队列为空时返回 false。
+func (d *Dispatcher) next() (*job, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for d.queue.Len() == 0 {
+		if d.closed {
+			return nil, false
+		}
+		d.cond.Wait()
+	}
+	j := heap.Pop(&d.queue).(*job)
+	<-d.slots
+	return j, true
+}
+
+func (d *Dispatcher) send(j *job) {
+	ctx := d.ctx
+	if !j.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, j.deadline)
+		defer cancel()
+	}
+
+	if err := d.waitRate(ctx); err != nil {
+		d.complete(j, nil, err)
+		return
+	}
+	result, err := d.api.Send(ctx, j.param)
+	d.observeRate(result, err)
+	d.complete(j, result, err)
+}
+
+func (d *Dispatcher) complete(j *job, result *SendResult, err error) {
+	if errors.Is(err, context.Canceled) && d.ctx.Err() != nil {
+		err = ErrDispatcherClosed
+	}
+	j.future.result, j.future.err = result, err
+	close(j.future.done)
+	if j.callback != nil {
+		j.callback(result, err)
+	}
+	if d.resultHandler != nil {
+		d.resultHandler(j.param, result, err)
+	}
+}
+
+// 如果 API 频率控制要求暂停发送，则等待直到时间窗口重置。
+func (d *Dispatcher) waitRate(ctx context.Context) error {
+	d.mu.Lock()
+	wait := time.Until(d.pausedUntil)
+	d.mu.Unlock()
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// 根据推送结果的 API 频率控制信息，在当前时间窗口的可用次数耗尽或返回 429 时暂停发送。
+func (d *Dispatcher) observeRate(result *SendResult, err error) {
+	var (
+		rate    api.Rate
+		limited bool
+	)
+	if result != nil && result.Response != nil {
+		rate, limited = result.Rate, result.StatusCode == http.StatusTooManyRequests
+	}
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		rate, limited = apiErr.Rate, apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	if !limited && (rate.Limit <= 0 || rate.Remaining > 0) {
+		return
+	}
+
+	reset := time.Duration(rate.Reset) * time.Second
+	if reset <= 0 {
+		reset = time.Second
+	}
+	until := time.Now().Add(reset)
+	d.mu.Lock()
+	if until.After(d.pausedUntil) {
+		d.pausedUntil = until
+	}
+	d.mu.Unlock()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 按优先级（高者优先）和提交顺序排列的推送任务队列。
+type jobQueue []*job
+
+func (q jobQueue) Len() int { return len(q) }
+
+func (q jobQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q jobQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *jobQueue) Push(x interface{}) { *q = append(*q, x.(*job)) }
+
+func (q *jobQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	j := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return j
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+)
+
+// 只实现了 Send 的 Push API v3。
+type sendFunc func(ctx context.Context, param *push.SendParam) (*push.SendResult, error)
+
+type fakeAPIv3 struct {
+	push.APIv3
+	send sendFunc
+}
+
+func (f *fakeAPIv3) Send(ctx context.Context, param *push.SendParam) (*push.SendResult, error) {
+	return f.send(ctx, param)
+}
+
+func newParam(alert string) *push.SendParam {
+	return &push.SendParam{
+		Platform:     platform.All,
+		Audience:     push.BroadcastAuds,
+		Notification: &push.Notification{Alert: alert},
+	}
+}
+
+func okResult() *push.SendResult {
+	return &push.SendResult{Response: &api.Response{StatusCode: 200}, MsgID: "1"}
+}
+
+// 第一次发送阻塞直到 release 被关闭，之后的发送按顺序记录推送内容。
+func blockingAPI() (*fakeAPIv3, chan struct{}, func() []string) {
+	var (
+		mu     sync.Mutex
+		alerts []string
+		once   sync.Once
+	)
+	started, release := make(chan struct{}), make(chan struct{})
+	fake := &fakeAPIv3{send: func(ctx context.Context, param *push.SendParam) (*push.SendResult, error) {
+		first := false
+		once.Do(func() { first = true })
+		if first {
+			close(started)
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		mu.Lock()
+		alerts = append(alerts, param.Notification.Alert)
+		mu.Unlock()
+		return okResult(), nil
+	}}
+	return fake, release, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), alerts...)
+	}
+}
+
+func TestDispatcherPriority(t *testing.T) {
+	fake, release, alerts := blockingAPI()
+	d := push.NewDispatcher(fake, push.WithDispatcherWorkers(1))
+	ctx := context.Background()
+
+	first, _ := d.Submit(ctx, newParam("first"))
+	for d.Len() > 0 { // 等待第一个推送任务被 worker 取走
+		time.Sleep(time.Millisecond)
+	}
+	_, _ = d.Submit(ctx, newParam("low"), push.WithJobPriority(push.PriorityLow))
+	_, _ = d.Submit(ctx, newParam("normal"))
+	_, _ = d.Submit(ctx, newParam("high"), push.WithJobPriority(push.PriorityHigh))
+	close(release)
+
+	if _, err := first.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"first", "high", "normal", "low"}
+	got := alerts()
+	if len(got) != len(want) {
+		t.Fatalf("alerts = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("alerts = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDispatcherDeadlineAndQueueFull(t *testing.T) {
+	fake, release, _ := blockingAPI()
+	d := push.NewDispatcher(fake, push.WithDispatcherWorkers(1), push.WithDispatcherQueueSize(1))
+	ctx := context.Background()
+
+	_, _ = d.Submit(ctx, newParam("first"))
+	for d.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	var (
+		callbackErr error
+		called      = make(chan struct{})
+	)
+	expired, err := d.Submit(ctx, newParam("expired"), push.WithJobTimeout(time.Millisecond), push.WithJobCallback(func(_ *push.SendResult, err error) {
+		callbackErr = err
+		close(called)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = d.TrySubmit(newParam("full")); !errors.Is(err, push.ErrQueueFull) {
+		t.Errorf("TrySubmit() = %v, want ErrQueueFull", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	close(release)
+
+	if _, err = expired.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expired job = %v, want context.DeadlineExceeded", err)
+	}
+	<-called
+	if !errors.Is(callbackErr, context.DeadlineExceeded) {
+		t.Errorf("callback error = %v, want context.DeadlineExceeded", callbackErr)
+	}
+	_ = d.Close(ctx)
+	if _, err = d.Submit(ctx, newParam("closed")); !errors.Is(err, push.ErrDispatcherClosed) {
+		t.Errorf("Submit() after Close = %v, want ErrDispatcherClosed", err)
+	}
+}
+
+func TestDispatcherCloseTimeout(t *testing.T) {
+	fake, _, _ := blockingAPI()
+	var pending []*push.SendParam
+	d := push.NewDispatcher(fake, push.WithDispatcherWorkers(1), push.WithDispatcherPendingHandler(func(params []*push.SendParam) error {
+		pending = params
+		return nil
+	}))
+	ctx := context.Background()
+
+	inflight, _ := d.Submit(ctx, newParam("inflight"))
+	for d.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	queued, _ := d.Submit(ctx, newParam("queued"))
+
+	closeCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := d.Close(closeCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close() = %v, want context.DeadlineExceeded", err)
+	}
+	if len(pending) != 1 || pending[0].Notification.Alert != "queued" {
+		t.Errorf("pending = %v, want the queued job", pending)
+	}
+	for _, f := range []*push.Future{inflight, queued} {
+		if _, err := f.Wait(ctx); !errors.Is(err, push.ErrDispatcherClosed) {
+			t.Errorf("job = %v, want ErrDispatcherClosed", err)
+		}
+	}
+}
+
+func TestDispatcherRateLimit(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+	fake := &fakeAPIv3{send: func(ctx context.Context, param *push.SendParam) (*push.SendResult, error) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		result := okResult()
+		result.Rate = api.Rate{Limit: 600, Remaining: 0, Reset: 1}
+		return result, nil
+	}}
+	d := push.NewDispatcher(fake, push.WithDispatcherWorkers(1))
+	ctx := context.Background()
+
+	_, _ = d.Submit(ctx, newParam("first"))
+	second, _ := d.Submit(ctx, newParam("second"))
+	if _, err := second.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_ = d.Close(ctx)
+	if wait := times[1].Sub(times[0]); wait < 900*time.Millisecond {
+		t.Errorf("second push sent after %v, want to wait for the rate limit reset", wait)
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, nil, androidDevice("rid1"))
+	var (
+		mu      sync.Mutex
+		handled int
+	)
+	d := push.NewDispatcher(pushAPIv3, push.WithDispatcherResultHandler(func(_ *push.SendParam, _ *push.SendResult, _ error) {
+		mu.Lock()
+		handled++
+		mu.Unlock()
+	}))
+	ctx := context.Background()
+
+	futures := make([]*push.Future, 10)
+	for i := range futures {
+		futures[i], _ = d.Submit(ctx, newParam("hello"))
+	}
+	if err := d.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range futures {
+		if result, err := f.Wait(ctx); err != nil || !result.IsSuccess() {
+			t.Errorf("job = %+v, %v", result, err)
+		}
+	}
+	if n := len(srv.Pushes()); n != 10 || handled != 10 {
+		t.Errorf("pushes = %d, handled = %d, want 10", n, handled)
+	}
+}
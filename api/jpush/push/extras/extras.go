@@ -0,0 +1,121 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package extras 提供通知和自定义消息中扩展字段（Extras）与业务结构体之间的类型化转换。
+//
+// 所有平台的 Extras 都使用同一种 JSON 编码规则：先将业务结构体按 encoding/json 序列化（遵循其 json 标签），
+// 再解析为 map[string]interface{}，其中数字统一保留为 json.Number，避免大整数在 float64 转换中丢失精度。
+package extras
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JPush 客户端 SDK 及服务端内部使用的 Extras 保留键，业务自定义的键与之冲突时，可能会被覆盖或导致客户端解析异常。
+//   - 此外，所有以 "_j" 开头的键同样被视为保留键；
+//   - 文档中允许开发者设置的键（如 xx_content_forshort、third_url_encode）不属于保留键。
+var ReservedKeys = []string{
+	"_j_msgid",
+	"_j_uid",
+	"_j_business",
+	"_j_data_",
+	"_j_extras",
+	"_jmsgid",
+	"msg_id",
+	"aps",
+	"JMessageExtra",
+}
+
+// 判断 `key` 是否为 JPush 保留的 Extras 键。
+func IsReserved(key string) bool {
+	if strings.HasPrefix(key, "_j") {
+		return true
+	}
+	for _, k := range ReservedKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// 返回 `extras` 中与 JPush 保留键冲突的所有键，按字典序排列。
+func ReservedKeysIn(extras map[string]interface{}) []string {
+	var keys []string
+	for k := range extras {
+		if IsReserved(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 将业务结构体 `payload` 编码为 Extras。
+//   - `payload` 必须编码为 JSON 对象（如结构体或 map），否则返回错误；
+//   - 编码结果中的数字为 json.Number，嵌套对象和数组分别为 map[string]interface{} 和 []interface{}。
+func Encode[T any](payload T) (map[string]interface{}, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode extras: %w", err)
+	}
+	if data = bytes.TrimSpace(data); len(data) == 0 || data[0] != '{' {
+		return nil, fmt.Errorf("encode extras: %T must be encoded as a JSON object", payload)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var extras map[string]interface{}
+	if err = dec.Decode(&extras); err != nil {
+		return nil, fmt.Errorf("encode extras: %w", err)
+	}
+	return extras, nil
+}
+
+// 将 Extras 解码为业务结构体 T，可用于回调处理或测试代码中还原推送时附加的 Extras。
+//   - `extras` 可以来自 Encode 的结果，也可以来自任意 JSON 反序列化得到的 map。
+func Decode[T any](extras map[string]interface{}) (T, error) {
+	var payload T
+	data, err := json.Marshal(extras)
+	if err != nil {
+		return payload, fmt.Errorf("decode extras: %w", err)
+	}
+	if err = json.Unmarshal(data, &payload); err != nil {
+		return payload, fmt.Errorf("decode extras: %w", err)
+	}
+	return payload, nil
+}
+
+// 将业务结构体 `payload` 编码后合并到 `*dst` 中，同名的键以 `payload` 为准，`*dst` 为 nil 时自动创建。
+//   - 编码失败时 `*dst` 保持不变并返回错误；
+//   - `payload` 中存在与 JPush 保留键冲突的键时，仍然完成合并，冲突的键通过 `reserved` 返回（按字典序排列），调用方可自行决定是否记录警告。
+func Merge[T any](dst *map[string]interface{}, payload T) (reserved []string, err error) {
+	extras, err := Encode(payload)
+	if err != nil {
+		return nil, err
+	}
+	if *dst == nil {
+		*dst = make(map[string]interface{}, len(extras))
+	}
+	for k, v := range extras {
+		(*dst)[k] = v
+	}
+	return ReservedKeysIn(extras), nil
+}
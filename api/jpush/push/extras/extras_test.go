@@ -0,0 +1,104 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extras_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/extras"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/message"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification"
+)
+
+type orderExtras struct {
+	OrderID int64    `json:"order_id"`
+	Status  string   `json:"status"`
+	Items   []string `json:"items,omitempty"`
+}
+
+func TestEncodeDecode(t *testing.T) {
+	payload := orderExtras{OrderID: 1<<62 + 1, Status: "shipped", Items: []string{"a", "b"}}
+	m, err := extras.Encode(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := m["order_id"].(json.Number); !ok || n.String() != "4611686018427387905" {
+		t.Errorf("expected order_id to be kept as json.Number, got %#v", m["order_id"])
+	}
+
+	got, err := extras.Decode[orderExtras](m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, payload) {
+		t.Errorf("got %+v, want %+v", got, payload)
+	}
+
+	if _, err = extras.Encode([]string{"not", "an", "object"}); err == nil {
+		t.Error("expected an error for non-object payload")
+	}
+}
+
+func TestNotificationWithExtras(t *testing.T) {
+	android := &notification.Android{Alert: "hi", Extras: map[string]interface{}{"mipns_content_forshort": "short"}}
+	if reserved, err := notification.WithExtras(android, orderExtras{OrderID: 1, Status: "paid"}); err != nil || len(reserved) != 0 {
+		t.Fatalf("WithExtras() = %v, %v", reserved, err)
+	}
+	if android.Extras["mipns_content_forshort"] != "short" || android.Extras["status"] != "paid" {
+		t.Errorf("expected extras to be merged, got %v", android.Extras)
+	}
+
+	ios := &notification.IOS{Alert: "hi"}
+	if _, err := notification.WithExtras[orderExtras](ios, orderExtras{OrderID: 1, Status: "paid"}); err != nil {
+		t.Fatal(err)
+	}
+	// 不同平台的编码结果一致。
+	a, _ := json.Marshal(android.Extras["order_id"])
+	i, _ := json.Marshal(ios.Extras["order_id"])
+	if string(a) != string(i) {
+		t.Errorf("expected consistent encoding, got %s and %s", a, i)
+	}
+
+	got, err := notification.DecodeExtras[orderExtras](ios)
+	if err != nil || got.Status != "paid" {
+		t.Errorf("unexpected decode result %+v, %v", got, err)
+	}
+
+	var hmos *notification.HMOS
+	if _, err = notification.WithExtras(hmos, orderExtras{}); err == nil {
+		t.Error("expected an error for nil notification")
+	}
+}
+
+func TestReservedKeys(t *testing.T) {
+	msg := &message.Custom{Content: "hello"}
+	reserved, err := message.WithExtras(msg, map[string]interface{}{"aps": 1, "_j_custom": 2, "biz": 3})
+	if err != nil {
+		t.Fatalf("expected no error for reserved keys, got %v", err)
+	}
+	if want := []string{"_j_custom", "aps"}; !reflect.DeepEqual(reserved, want) {
+		t.Errorf("got %v, want %v", reserved, want)
+	}
+	if len(msg.Extras) != 3 {
+		t.Errorf("expected extras to be set despite the warning, got %v", msg.Extras)
+	}
+
+	got, err := message.DecodeExtras[map[string]int](msg)
+	if err != nil || got["biz"] != 3 {
+		t.Errorf("unexpected decode result %v, %v", got, err)
+	}
+}
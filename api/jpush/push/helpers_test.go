@@ -0,0 +1,49 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+// 启动模拟的极光服务端并注册给定的设备，返回服务端和连接到该服务端的推送 API，服务端在测试结束时关闭。
+//   - `configure` 不为 nil 时，用于在创建客户端之前调整服务端提供的配置。
+func newPushServer(t *testing.T, configure func(*sdk.Config), devices ...jiguangtest.Device) (*jiguangtest.Server, push.APIv3) {
+	t.Helper()
+	srv := jiguangtest.NewServer()
+	t.Cleanup(srv.Close)
+	for _, device := range devices {
+		srv.AddDevice(device)
+	}
+	cfg := srv.Config()
+	if configure != nil {
+		configure(&cfg)
+	}
+	pushAPIv3, err := sdk.NewClient(cfg).Push()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, pushAPIv3
+}
+
+// 别名为 "alias-" + `rid` 的 Android 模拟设备。
+func androidDevice(rid string) jiguangtest.Device {
+	return jiguangtest.Device{RegistrationID: rid, Platform: platform.Android, Alias: "alias-" + rid}
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/liveactivity"
+)
+
+// 基于 Push API v3 创建实时活动事件的发送函数，用于 liveactivity.NewManager。
+//   - 每个事件作为一条推送平台为 iOS、推送目标为 LiveActivityID 的推送发送；
+//   - `apnsProduction` 指定 APNs 的推送环境，为 nil 时按照 JPush 的默认值推送生产环境；
+//   - 推送结果不成功时返回 *api.APIError。
+func LiveActivitySender(pushAPIv3 APIv3, apnsProduction *bool) liveactivity.Sender {
+	return func(ctx context.Context, activityID string, msg *liveactivity.Message) (string, error) {
+		if pushAPIv3 == nil {
+			return "", errors.New("`pushAPIv3` cannot be nil")
+		}
+		param := &SendParam{
+			Platform:     []platform.Platform{platform.IOS},
+			Audience:     &Audience{LiveActivityID: activityID},
+			LiveActivity: msg,
+		}
+		if apnsProduction != nil {
+			param.Options = &Options{ApnsProduction: apnsProduction}
+		}
+		result, err := pushAPIv3.Send(ctx, param)
+		if err != nil {
+			return "", err
+		}
+		if !result.IsSuccess() {
+			return "", api.NewAPIError(http.MethodPost+" /v3/push", result.Response, result.Error)
+		}
+		return result.MsgID, nil
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/liveactivity"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest"
+)
+
+func TestLiveActivitySender(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, nil, jiguangtest.Device{RegistrationID: "rid1", Platform: platform.IOS})
+	production := false
+	m, err := liveactivity.NewManager(push.LiveActivitySender(pushAPIv3, &production))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	ctx := context.Background()
+	if _, err = m.Start(ctx, "la1", &liveactivity.IosMessage{AttributesType: "Delivery", ContentState: map[string]interface{}{"eta": 10}}); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.RequestsTo("POST", "/v3/push")
+	if len(reqs) != 1 {
+		t.Fatalf("expected 1 push request, got %d", len(reqs))
+	}
+	var body struct {
+		Audience     push.Audience            `json:"audience"`
+		Options      push.Options             `json:"options"`
+		LiveActivity push.LiveActivityMessage `json:"live_activity"`
+	}
+	if err = reqs[0].Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Audience.LiveActivityID != "la1" || body.LiveActivity.IOS.Event != push.LiveActivityEventStart {
+		t.Errorf("unexpected push body %+v", body)
+	}
+	if body.Options.ApnsProduction == nil || *body.Options.ApnsProduction {
+		t.Errorf("expected apns_production to be false, got %v", body.Options.ApnsProduction)
+	}
+
+	srv.Fail(jiguangtest.Failure{Method: "POST", Path: "/v3/push", Code: 1011, Message: "cannot find user"})
+	if _, err = m.Update(ctx, "la1", &liveactivity.IosMessage{ContentState: map[string]interface{}{"eta": 5}}); err == nil {
+		t.Error("expected an error when the push fails")
+	}
+	if a, _ := m.Get(ctx, "la1"); a.Sequence != 1 {
+		t.Errorf("expected the failed update not to be recorded, got sequence %d", a.Sequence)
+	}
+}
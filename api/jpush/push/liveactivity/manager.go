@@ -0,0 +1,325 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liveactivity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidTransition = errors.New("invalid live activity state transition") // 当前状态下不允许发送该事件。
+	ErrStale             = errors.New("live activity is stale")                 // 实时活动已过期，不会再被更新。
+)
+
+// 通过推送接口向指定的实时活动发送事件，返回推送消息 ID，可使用 push.LiveActivitySender 基于 push.APIv3 创建。
+type Sender func(ctx context.Context, activityID string, msg *Message) (msgID string, err error)
+
+// # 实时活动生命周期管理器
+//
+// 负责创建实时活动记录、通过 Sender 发送 start、update 和 end 事件，并保证事件按照合法的状态转换发送：
+//   - Start 只能用于尚不存在的实时活动，发送成功后状态为 StateActive；
+//   - Update 只能用于状态为 StateActive 且未过期的实时活动；
+//   - End 只能用于状态为 StateActive 的实时活动（包括已过期的），发送成功后状态为 StateEnded，之后不能再发送任何事件；
+//   - 每次发送成功后，事件序号递增，实时活动动态内容（content-state）追加到 Store 的事件历史中；发送失败时状态保持不变。
+//
+// 通过 WithAutoEnd 启用后，Manager 会在后台定期结束已过期的实时活动，使用完毕后需要调用 Close 关闭。
+//
+// 可被多个 goroutine 并发使用，同一个实时活动的事件按调用顺序串行发送。
+//
+//	manager, err := liveactivity.NewManager(push.LiveActivitySender(pushAPIv3, nil), liveactivity.WithAutoEnd(time.Minute))
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer manager.Close()
+//
+//	_, err = manager.Start(ctx, "order-1001", &liveactivity.IosMessage{
+//		AttributesType: "DeliveryAttributes",
+//		ContentState:   map[string]interface{}{"status": "preparing"},
+//	})
+type Manager struct {
+	sender       Sender
+	store        Store
+	staleAfter   time.Duration
+	autoEnd      time.Duration
+	errorHandler func(err error)
+
+	locksMu sync.Mutex
+	locks   map[string]*activityLock // 实时活动标识 -> 正在使用的锁，不再被使用时即被删除
+
+	stop     chan struct{}
+	done     chan struct{}
+	closeMu  sync.Mutex
+	isClosed bool
+}
+
+// 用于配置 Manager 的选项。
+type ManagerOption func(*Manager)
+
+// 设置实时活动记录的存储，默认为 NewMemoryStore()。
+func WithStore(store Store) ManagerOption {
+	return func(m *Manager) {
+		if store != nil {
+			m.store = store
+		}
+	}
+}
+
+// 设置 start 和 update 事件未指定 StaleDate 时的默认过期时长，即 StaleDate 为发送时间加上 `d`，默认不设置。
+func WithStaleAfter(d time.Duration) ManagerOption {
+	return func(m *Manager) {
+		if d > 0 {
+			m.staleAfter = d
+		}
+	}
+}
+
+// 启用自动结束已过期的实时活动，每隔 `interval` 调用一次 EndStale，默认不启用。
+func WithAutoEnd(interval time.Duration) ManagerOption {
+	return func(m *Manager) {
+		if interval > 0 {
+			m.autoEnd = interval
+		}
+	}
+}
+
+// 设置自动结束已过期的实时活动失败时的错误处理函数，如用于记录日志或指标，默认忽略错误。
+func WithErrorHandler(handler func(err error)) ManagerOption {
+	return func(m *Manager) {
+		m.errorHandler = handler
+	}
+}
+
+// 创建实时活动生命周期管理器，启用了 WithAutoEnd 时会启动后台 goroutine，使用完毕后需要调用 Close 关闭。
+func NewManager(sender Sender, opts ...ManagerOption) (*Manager, error) {
+	if sender == nil {
+		return nil, errors.New("`sender` cannot be nil")
+	}
+	m := &Manager{
+		sender: sender,
+		store:  NewMemoryStore(),
+		locks:  make(map[string]*activityLock),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.autoEnd > 0 {
+		go m.run()
+	} else {
+		close(m.done)
+	}
+	return m, nil
+}
+
+// 停止自动结束已过期的实时活动，并等待正在进行的 EndStale 完成；已经开始的 Start、Update 和 End 调用不受影响。
+func (m *Manager) Close() error {
+	m.closeMu.Lock()
+	if !m.isClosed {
+		m.isClosed = true
+		close(m.stop)
+	}
+	m.closeMu.Unlock()
+	<-m.done
+	return nil
+}
+
+func (m *Manager) run() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.autoEnd)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-m.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			if _, err := m.EndStale(ctx); err != nil && m.errorHandler != nil && ctx.Err() == nil {
+				m.errorHandler(err)
+			}
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 创建实时活动并发送 start 事件，`msg` 的 Event 会被设置为 EventStart，AttributesType 和 ContentState 不能为空。
+//   - `id` 对应推送设备对象的 LiveActivityID；
+//   - 实时活动已存在（包括已结束的）时返回 ErrInvalidTransition。
+func (m *Manager) Start(ctx context.Context, id string, msg *IosMessage) (*Activity, error) {
+	if id == "" {
+		return nil, errors.New("`id` cannot be empty")
+	}
+	if msg == nil || msg.AttributesType == "" {
+		return nil, errors.New("`msg.AttributesType` cannot be empty for start event")
+	}
+	return m.transition(ctx, id, EventStart, msg)
+}
+
+// 发送 update 事件更新实时活动，`msg` 的 Event 会被设置为 EventUpdate，ContentState 不能为空。
+//   - 实时活动不存在时返回 ErrNotFound，已结束时返回 ErrInvalidTransition，已过期时返回 ErrStale。
+func (m *Manager) Update(ctx context.Context, id string, msg *IosMessage) (*Activity, error) {
+	return m.transition(ctx, id, EventUpdate, msg)
+}
+
+// 发送 end 事件结束实时活动，`msg` 的 Event 会被设置为 EventEnd。
+//   - `msg` 为 nil 或 ContentState 为空时，使用最近一次发送的实时活动动态内容；
+//   - 实时活动不存在时返回 ErrNotFound，已结束时返回 ErrInvalidTransition。
+func (m *Manager) End(ctx context.Context, id string, msg *IosMessage) (*Activity, error) {
+	return m.transition(ctx, id, EventEnd, msg)
+}
+
+// 结束所有已过期的实时活动，返回成功结束的实时活动标识，部分失败时同时返回所有失败的错误。
+func (m *Manager) EndStale(ctx context.Context) ([]string, error) {
+	active, err := m.store.Active(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ended []string
+	var errs []error
+	for _, a := range active {
+		if !a.IsStale(time.Now()) {
+			continue
+		}
+		if _, err = m.End(ctx, a.ID, nil); err != nil {
+			// 已被其他调用方结束的实时活动不视为错误。
+			if !errors.Is(err, ErrInvalidTransition) {
+				errs = append(errs, fmt.Errorf("end stale live activity %q: %w", a.ID, err))
+			}
+			continue
+		}
+		ended = append(ended, a.ID)
+	}
+	return ended, errors.Join(errs...)
+}
+
+// 获取实时活动记录，不存在时返回 ErrNotFound。
+func (m *Manager) Get(ctx context.Context, id string) (*Activity, error) {
+	return m.store.Get(ctx, id)
+}
+
+// 获取实时活动的所有事件记录，按事件序号升序排列，不存在时返回 ErrNotFound。
+func (m *Manager) History(ctx context.Context, id string) ([]Snapshot, error) {
+	return m.store.History(ctx, id)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 同一实时活动的锁，refs 为正在持有或等待该锁的调用数量。
+type activityLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// 获取实时活动 `id` 的锁，返回释放锁的函数；锁在没有调用方持有或等待时被删除，避免长期运行时无限增长。
+func (m *Manager) lock(id string) func() {
+	m.locksMu.Lock()
+	l := m.locks[id]
+	if l == nil {
+		l = &activityLock{}
+		m.locks[id] = l
+	}
+	l.refs++
+	m.locksMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		m.locksMu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(m.locks, id)
+		}
+		m.locksMu.Unlock()
+	}
+}
+
+// 校验状态转换，发送事件，并在发送成功后更新实时活动记录和事件历史。
+func (m *Manager) transition(ctx context.Context, id string, event Event, msg *IosMessage) (*Activity, error) {
+	unlock := m.lock(id)
+	defer unlock()
+
+	now := time.Now()
+	a, err := m.store.Get(ctx, id)
+	switch {
+	case event == EventStart && err == nil:
+		return nil, fmt.Errorf("%w: cannot start %s live activity %q", ErrInvalidTransition, a.State, id)
+	case event == EventStart && errors.Is(err, ErrNotFound):
+		a = &Activity{ID: id, AttributesType: msg.AttributesType, Attributes: maps.Clone(msg.Attributes), StartedAt: now}
+	case err != nil:
+		return nil, err
+	case a.State != StateActive:
+		return nil, fmt.Errorf("%w: cannot %s %s live activity %q", ErrInvalidTransition, event, a.State, id)
+	case event == EventUpdate && a.IsStale(now):
+		return nil, fmt.Errorf("%w: cannot update live activity %q stale since %s", ErrStale, id, a.StaleDate.Format(time.RFC3339))
+	}
+
+	var ios IosMessage
+	if msg != nil {
+		ios = *msg
+	}
+	ios.Event = event
+	if len(ios.ContentState) == 0 {
+		if event != EventEnd {
+			return nil, fmt.Errorf("`msg.ContentState` cannot be empty for %s event", event)
+		}
+		ios.ContentState = a.ContentState
+	}
+	if ios.StaleDate == 0 && m.staleAfter > 0 && event != EventEnd {
+		ios.StaleDate = now.Add(m.staleAfter).Unix()
+	}
+
+	msgID, err := m.sender(ctx, id, &Message{IOS: &ios})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Sequence++
+	a.ContentState = maps.Clone(ios.ContentState)
+	a.UpdatedAt = now
+	if ios.StaleDate != 0 {
+		a.StaleDate = time.Unix(ios.StaleDate, 0)
+	}
+	if ios.DismissalDate != 0 {
+		a.DismissalDate = time.Unix(ios.DismissalDate, 0)
+	}
+	if event == EventEnd {
+		a.State, a.EndedAt = StateEnded, now
+	} else {
+		a.State = StateActive
+	}
+	if err = m.store.Save(ctx, a); err != nil {
+		return nil, err
+	}
+	snapshot := Snapshot{Sequence: a.Sequence, Event: event, ContentState: a.ContentState, MsgID: msgID, Time: now}
+	if err = m.store.Append(ctx, id, snapshot); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
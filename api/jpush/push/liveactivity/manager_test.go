@@ -0,0 +1,216 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liveactivity_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/liveactivity"
+)
+
+// 记录发送的事件，`fail` 不为 nil 时发送失败。
+type recorder struct {
+	mu     sync.Mutex
+	events []liveactivity.IosMessage
+	fail   error
+}
+
+func (r *recorder) send(_ context.Context, _ string, msg *liveactivity.Message) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail != nil {
+		return "", r.fail
+	}
+	r.events = append(r.events, *msg.IOS)
+	return fmt.Sprintf("msg%d", len(r.events)), nil
+}
+
+func (r *recorder) sent() []liveactivity.IosMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]liveactivity.IosMessage(nil), r.events...)
+}
+
+func state(status string) map[string]interface{} {
+	return map[string]interface{}{"status": status}
+}
+
+func TestManagerLifecycle(t *testing.T) {
+	rec := &recorder{}
+	m, err := liveactivity.NewManager(rec.send, liveactivity.WithStaleAfter(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	ctx := context.Background()
+
+	if _, err = m.Update(ctx, "order1", &liveactivity.IosMessage{ContentState: state("shipped")}); !errors.Is(err, liveactivity.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before start, got %v", err)
+	}
+	if _, err = m.Start(ctx, "order1", &liveactivity.IosMessage{ContentState: state("paid")}); err == nil {
+		t.Fatal("expected an error when attributes type is missing")
+	}
+
+	a, err := m.Start(ctx, "order1", &liveactivity.IosMessage{AttributesType: "Delivery", ContentState: state("paid")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.State != liveactivity.StateActive || a.Sequence != 1 || a.StaleDate.IsZero() {
+		t.Errorf("unexpected activity after start: %+v", a)
+	}
+	if _, err = m.Start(ctx, "order1", &liveactivity.IosMessage{AttributesType: "Delivery", ContentState: state("paid")}); !errors.Is(err, liveactivity.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition on restart, got %v", err)
+	}
+
+	rec.fail = errors.New("network down")
+	if _, err = m.Update(ctx, "order1", &liveactivity.IosMessage{ContentState: state("lost")}); err == nil {
+		t.Fatal("expected the send error")
+	}
+	rec.fail = nil
+
+	if a, err = m.Update(ctx, "order1", &liveactivity.IosMessage{ContentState: state("shipped")}); err != nil || a.Sequence != 2 {
+		t.Fatalf("Update() = %+v, %v", a, err)
+	}
+	if a, err = m.End(ctx, "order1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if a.State != liveactivity.StateEnded || a.EndedAt.IsZero() || a.ContentState["status"] != "shipped" {
+		t.Errorf("unexpected activity after end: %+v", a)
+	}
+	if _, err = m.Update(ctx, "order1", &liveactivity.IosMessage{ContentState: state("again")}); !errors.Is(err, liveactivity.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition after end, got %v", err)
+	}
+
+	events := rec.sent()
+	wantEvents := []liveactivity.Event{liveactivity.EventStart, liveactivity.EventUpdate, liveactivity.EventEnd}
+	if len(events) != len(wantEvents) {
+		t.Fatalf("expected %d events, got %d", len(wantEvents), len(events))
+	}
+	for i, e := range events {
+		if e.Event != wantEvents[i] {
+			t.Errorf("event %d: got %s, want %s", i, e.Event, wantEvents[i])
+		}
+	}
+	if events[2].ContentState["status"] != "shipped" {
+		t.Errorf("expected end event to reuse the last content state, got %v", events[2].ContentState)
+	}
+
+	history, err := m.History(ctx, "order1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 || history[1].ContentState["status"] != "shipped" || history[2].MsgID != "msg3" {
+		t.Errorf("unexpected history %+v", history)
+	}
+}
+
+func TestManagerEndStale(t *testing.T) {
+	rec := &recorder{}
+	ended := make(chan struct{})
+	store := liveactivity.NewMemoryStore()
+	m, err := liveactivity.NewManager(rec.send, liveactivity.WithStore(store), liveactivity.WithAutoEnd(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Minute).Unix()
+	if _, err = m.Start(ctx, "stale", &liveactivity.IosMessage{AttributesType: "Delivery", ContentState: state("paid"), StaleDate: past}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Start(ctx, "fresh", &liveactivity.IosMessage{AttributesType: "Delivery", ContentState: state("paid")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Update(ctx, "stale", &liveactivity.IosMessage{ContentState: state("shipped")}); !errors.Is(err, liveactivity.ErrStale) {
+		t.Errorf("expected ErrStale, got %v", err)
+	}
+
+	go func() {
+		defer close(ended)
+		for {
+			if a, _ := store.Get(ctx, "stale"); a != nil && a.State == liveactivity.StateEnded {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+	select {
+	case <-ended:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the stale activity to be ended automatically")
+	}
+
+	if a, _ := m.Get(ctx, "fresh"); a.State != liveactivity.StateActive {
+		t.Errorf("expected the fresh activity to stay active, got %s", a.State)
+	}
+}
+
+func TestManagerConcurrentEvents(t *testing.T) {
+	rec := &recorder{}
+	m, err := liveactivity.NewManager(rec.send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	ctx := context.Background()
+
+	if _, err = m.Start(ctx, "order1", &liveactivity.IosMessage{AttributesType: "Delivery", ContentState: state("paid")}); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if i == 10 {
+				_, err = m.End(ctx, "order1", nil)
+			} else {
+				_, err = m.Update(ctx, "order1", &liveactivity.IosMessage{ContentState: state(fmt.Sprint(i))})
+			}
+			if err != nil && !errors.Is(err, liveactivity.ErrInvalidTransition) {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	history, err := m.History(ctx, "order1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(rec.sent()); n != len(history) {
+		t.Fatalf("expected one history entry per sent event, got %d events and %d entries", n, len(history))
+	}
+	for i, s := range history {
+		if s.Sequence != i+1 {
+			t.Fatalf("expected consecutive sequences, got %d at %d", s.Sequence, i)
+		}
+	}
+	if last := history[len(history)-1]; last.Event != liveactivity.EventEnd {
+		t.Errorf("expected no event after end, got %s", last.Event)
+	}
+
+	// 结束后同一实时活动的后续调用仍然被正确拒绝。
+	if _, err = m.Start(ctx, "order1", &liveactivity.IosMessage{AttributesType: "Delivery", ContentState: state("paid")}); !errors.Is(err, liveactivity.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition on restart, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liveactivity
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("live activity not found") // 实时活动记录不存在。
+
+// # 实时活动的状态
+type State string
+
+const (
+	StateActive State = "active" // 已创建，可以更新或结束
+	StateEnded  State = "ended"  // 已结束，不能再发送任何事件
+)
+
+// # 实时活动记录
+//
+// 由 Manager 在发送事件成功后创建和更新，保存在 Store 中。
+type Activity struct {
+	ID             string                 // 实时活动标识，对应推送设备对象的 LiveActivityID
+	State          State                  // 实时活动的状态
+	AttributesType string                 // 实时活动属性类型
+	Attributes     map[string]interface{} // 实时活动属性
+	ContentState   map[string]interface{} // 最近一次发送的实时活动动态内容
+	Sequence       int                    // 最近一次发送的事件序号，从 1 开始递增
+	StaleDate      time.Time              // 实时活动显示过期时间，零值表示未设置
+	DismissalDate  time.Time              // 实时活动结束展示时间，零值表示未设置
+	StartedAt      time.Time              // 发送 start 事件的时间
+	UpdatedAt      time.Time              // 最近一次发送事件的时间
+	EndedAt        time.Time              // 发送 end 事件的时间，未结束时为零值
+}
+
+// 判断实时活动在 `now` 时是否已过期，即设置了 StaleDate 且不晚于 `now`。
+func (a *Activity) IsStale(now time.Time) bool {
+	return a != nil && !a.StaleDate.IsZero() && !a.StaleDate.After(now)
+}
+
+func (a *Activity) clone() *Activity {
+	c := *a
+	c.Attributes = maps.Clone(a.Attributes)
+	c.ContentState = maps.Clone(a.ContentState)
+	return &c
+}
+
+// # 实时活动的事件记录
+//
+// 每次成功发送事件后追加到 Store 中，用于追溯实时活动动态内容（content-state）的变更历史。
+type Snapshot struct {
+	Sequence     int                    // 事件序号
+	Event        Event                  // 事件类型
+	ContentState map[string]interface{} // 发送的实时活动动态内容
+	MsgID        string                 // 推送消息 ID
+	Time         time.Time              // 发送时间
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 实时活动记录的存储
+//
+// 用于保存实时活动记录及其事件历史，可基于数据库、Redis 等自行实现，以便在多个进程之间共享或在重启后恢复。
+//   - 实现需要可被多个 goroutine 并发使用；
+//   - Manager 对同一个实时活动的调用是串行的，但不同实时活动之间可能并发调用。
+type Store interface {
+	// 获取实时活动记录，不存在时返回 ErrNotFound。
+	Get(ctx context.Context, id string) (*Activity, error)
+	// 创建或替换实时活动记录。
+	Save(ctx context.Context, activity *Activity) error
+	// 追加实时活动的事件记录。
+	Append(ctx context.Context, id string, snapshot Snapshot) error
+	// 获取实时活动的所有事件记录，按事件序号升序排列。
+	History(ctx context.Context, id string) ([]Snapshot, error)
+	// 获取所有状态为 StateActive 的实时活动记录。
+	Active(ctx context.Context) ([]*Activity, error)
+}
+
+// 基于内存的 Store 实现，进程退出后数据丢失，适用于单进程部署和测试。
+type MemoryStore struct {
+	mu         sync.RWMutex
+	activities map[string]*Activity
+	history    map[string][]Snapshot
+}
+
+// 创建基于内存的 Store。
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		activities: make(map[string]*Activity),
+		history:    make(map[string][]Snapshot),
+	}
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (*Activity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	a, ok := s.activities[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return a.clone(), nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, activity *Activity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.activities[activity.ID] = activity.clone()
+	return nil
+}
+
+func (s *MemoryStore) Append(_ context.Context, id string, snapshot Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot.ContentState = maps.Clone(snapshot.ContentState)
+	s.history[id] = append(s.history[id], snapshot)
+	return nil
+}
+
+func (s *MemoryStore) History(_ context.Context, id string) ([]Snapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.activities[id]; !ok {
+		return nil, ErrNotFound
+	}
+	return slices.Clone(s.history[id]), nil
+}
+
+func (s *MemoryStore) Active(_ context.Context) ([]*Activity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var active []*Activity
+	for _, a := range s.activities {
+		if a.State == StateActive {
+			active = append(active, a.clone())
+		}
+	}
+	slices.SortFunc(active, func(a, b *Activity) int { return a.StartedAt.Compare(b.StartedAt) })
+	return active, nil
+}
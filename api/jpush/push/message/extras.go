@@ -0,0 +1,40 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"errors"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/extras"
+)
+
+// 将业务结构体 `payload` 编码后合并到自定义消息 `m` 的 Extras 中，同名的键以 `payload` 为准。
+//   - 与通知使用同一种 JSON 编码规则，详见 extras.Encode；
+//   - `payload` 中存在与 JPush 保留键冲突的键时，仍然完成合并，冲突的键通过 `reserved` 返回，详见 extras.Merge。
+func WithExtras[T any](m *Custom, payload T) (reserved []string, err error) {
+	if m == nil {
+		return nil, errors.New("`m` cannot be nil")
+	}
+	return extras.Merge(&m.Extras, payload)
+}
+
+// 将自定义消息 `m` 的 Extras 解码为业务结构体 T。
+func DecodeExtras[T any](m *Custom) (T, error) {
+	if m == nil {
+		var zero T
+		return zero, errors.New("`m` cannot be nil")
+	}
+	return extras.Decode[T](m.Extras)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"errors"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/extras"
+)
+
+// 支持类型化 Extras 的平台通知类型。
+type ExtrasCarrier interface {
+	*Android | *IOS | *HMOS | *QuickApp
+}
+
+// 将业务结构体 `payload` 编码后合并到平台通知 `n` 的 Extras 中，同名的键以 `payload` 为准。
+//   - 所有平台使用同一种 JSON 编码规则，详见 extras.Encode；
+//   - `payload` 中存在与 JPush 保留键冲突的键时，仍然完成合并，冲突的键通过 `reserved` 返回，详见 extras.Merge。
+//
+// 示例：
+//
+//	reserved, err := notification.WithExtras[OrderExtras](android, OrderExtras{OrderID: "1001", Status: "shipped"})
+func WithExtras[T any, N ExtrasCarrier](n N, payload T) (reserved []string, err error) {
+	dst := extrasOf(n)
+	if dst == nil {
+		return nil, errors.New("`n` cannot be nil")
+	}
+	return extras.Merge(dst, payload)
+}
+
+// 将平台通知 `n` 的 Extras 解码为业务结构体 T。
+func DecodeExtras[T any, N ExtrasCarrier](n N) (T, error) {
+	src := extrasOf(n)
+	if src == nil {
+		var zero T
+		return zero, errors.New("`n` cannot be nil")
+	}
+	return extras.Decode[T](*src)
+}
+
+// 返回平台通知 Extras 字段的指针，`n` 为 nil 时返回 nil。
+func extrasOf[N ExtrasCarrier](n N) *map[string]interface{} {
+	switch v := any(n).(type) {
+	case *Android:
+		if v != nil {
+			return &v.Extras
+		}
+	case *IOS:
+		if v != nil {
+			return &v.Extras
+		}
+	case *HMOS:
+		if v != nil {
+			return &v.Extras
+		}
+	case *QuickApp:
+		if v != nil {
+			return &v.Extras
+		}
+	}
+	return nil
+}
@@ -76,10 +76,12 @@ type HMOS struct {
 	//  - 0: 通知消息 (hmos.PushTypeAlert)
 	//  - 2: 通知拓展消息 (hmos.PushTypeExtension)
 	//  - 10: VoIP 呼叫消息 (hmos.PushTypeVoIPCall)
-	// 其它值报错，VoIP 消息与通知消息互斥，不可同时下发。
+	// 其它值（如卡片刷新、后台、实况窗消息）报错，VoIP 消息与通知消息互斥，不可同时下发。
+	//
+	// 不同推送类型的必填和禁用字段差异较大，建议使用 NewHMOSAlert、NewHMOSExtension、NewHMOSVoIPCall 构造函数创建，详见 HMOS.Validate 说明。
 	PushType hmos.PushType `json:"push_type"`
 	// 【可选】附加数据。
-	//  - 对应华为 extraData 字段，当 PushType = hmos.PushTypeExtension 或 PushType = hmos.PushTypeAlert 时生效，此时是必填的，
+	//  - 对应华为 extraData 字段，当 PushType 为 hmos.PushTypeExtension、hmos.PushTypeVoIPCall 等场景化消息时生效，此时是必填的，
 	//  PushType = hmos.PushTypeAlert 时忽略此字段。
 	ExtraData string `json:"extra_data,omitempty"`
 	// 【可选】APP 在前台，通知是否展示。
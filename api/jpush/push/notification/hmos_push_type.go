@@ -0,0 +1,156 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/hmos"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/style"
+)
+
+// 创建鸿蒙通知消息（hmos.PushTypeAlert），`alert` 和 `category` 不能为空。
+func NewHMOSAlert(alert, category string) (*HMOS, error) {
+	return checkHMOS(&HMOS{Alert: alert, Category: category, PushType: hmos.PushTypeAlert})
+}
+
+// 创建鸿蒙通知扩展消息（hmos.PushTypeExtension），`alert` 和 `category` 不能为空，`data` 会被编码为 JSON 作为 ExtraData 传递给应用的通知扩展进程。
+func NewHMOSExtension(alert, category string, data interface{}) (*HMOS, error) {
+	extraData, err := encodeExtraData(data)
+	if err != nil {
+		return nil, err
+	}
+	return checkHMOS(&HMOS{Alert: alert, Category: category, PushType: hmos.PushTypeExtension, ExtraData: extraData})
+}
+
+// 创建鸿蒙应用内通话消息（hmos.PushTypeVoIPCall），`data` 为应用自定义的呼叫数据，会被编码为 JSON 作为 ExtraData。
+//   - VoIP 消息与通知消息互斥，不可同时下发。
+func NewHMOSVoIPCall(data interface{}) (*HMOS, error) {
+	extraData, err := encodeExtraData(data)
+	if err != nil {
+		return nil, err
+	}
+	return checkHMOS(&HMOS{PushType: hmos.PushTypeVoIPCall, ExtraData: extraData})
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 在本地按照华为场景化消息的约束校验鸿蒙通知，校验通过时返回 nil，否则返回 api.ValidationErrors，字段路径与 JSON 字段名一致。
+//   - 极光目前仅支持通知消息、通知扩展消息和应用内通话消息，卡片刷新、后台和实况窗等其它推送类型会被服务端拒绝，此处同样报错；
+//   - 通知消息、通知扩展消息：Alert 和 Category 必填，通知扩展消息的 ExtraData 必填，通知消息不能设置 ExtraData；
+//   - 应用内通话消息：ExtraData 必填，且不能设置 Alert、Category、Title、LargeIcon、Intent、角标和样式等通知展示相关的字段，
+//     其中为空的 Alert 和 Category 在序列化时会被省略，不会以空字符串下发；
+//   - ExtraData 必须是有效的 JSON，BadgeAddNum、BadgeSetNum、Style、DisplayForeground 等取值在有效范围内。
+func (h *HMOS) Validate() error {
+	if h == nil {
+		return nil
+	}
+	var errs api.ValidationErrors
+	if h.ExtraData != "" && !json.Valid([]byte(h.ExtraData)) {
+		errs.Add("extra_data", "must be valid JSON")
+	}
+
+	switch h.PushType {
+	case hmos.PushTypeAlert, hmos.PushTypeExtension:
+		if h.Alert == "" {
+			errs.Add("alert", "is required for push type %d", h.PushType)
+		}
+		if h.Category == "" {
+			errs.Add("category", "is required for push type %d", h.PushType)
+		}
+		if h.PushType == hmos.PushTypeAlert && h.ExtraData != "" {
+			errs.Add("extra_data", "is not allowed for push type %d", h.PushType)
+		}
+		if h.PushType == hmos.PushTypeExtension && h.ExtraData == "" {
+			errs.Add("extra_data", "is required for push type %d", h.PushType)
+		}
+	case hmos.PushTypeVoIPCall:
+		if h.ExtraData == "" {
+			errs.Add("extra_data", "is required for push type %d", h.PushType)
+		}
+		forbid := func(field string, set bool) {
+			if set {
+				errs.Add(field, "is not allowed for push type %d", h.PushType)
+			}
+		}
+		forbid("alert", h.Alert != "")
+		forbid("category", h.Category != "")
+		forbid("title", h.Title != "")
+		forbid("large_icon", h.LargeIcon != "")
+		forbid("intent", h.Intent != nil)
+		forbid("badge_add_num", h.BadgeAddNum != nil)
+		forbid("badge_set_num", h.BadgeSetNum != nil)
+		forbid("style", h.Style != 0)
+		forbid("inbox", h.Inbox != nil)
+		forbid("display_foreground", h.DisplayForeground != "")
+	default:
+		errs.Add("push_type", "unsupported push type %d, only 0, 2 and 10 are supported", h.PushType)
+	}
+
+	if h.BadgeAddNum != nil && (*h.BadgeAddNum < 1 || *h.BadgeAddNum > 99) {
+		errs.Add("badge_add_num", "must be between 1 and 99, got %d", *h.BadgeAddNum)
+	}
+	if h.BadgeSetNum != nil && (*h.BadgeSetNum < 0 || *h.BadgeSetNum > 99) {
+		errs.Add("badge_set_num", "must be between 0 and 99, got %d", *h.BadgeSetNum)
+	}
+	if h.Style != 0 && h.Style != style.Inbox {
+		errs.Add("style", "must be 0 or style.Inbox (2), got %d", h.Style)
+	}
+	if h.Style == style.Inbox && len(h.Inbox) == 0 {
+		errs.Add("inbox", "is required when style is style.Inbox")
+	}
+	switch h.DisplayForeground {
+	case "", "0", "1":
+	default:
+		errs.Add("display_foreground", "must be \"0\" or \"1\", got %q", h.DisplayForeground)
+	}
+	return errs.Err()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func encodeExtraData(data interface{}) (string, error) {
+	if data == nil {
+		return "", errors.New("`data` cannot be nil")
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("encode extra_data: %w", err)
+	}
+	return string(b), nil
+}
+
+// 应用内通话消息不展示通知，序列化时省略为空的 Alert 和 Category，避免以空字符串下发导致厂商返回失败。
+func (h HMOS) MarshalJSON() ([]byte, error) {
+	type Alias HMOS
+	if h.PushType != hmos.PushTypeVoIPCall {
+		return json.Marshal(Alias(h))
+	}
+	return json.Marshal(&struct {
+		Alert    string `json:"alert,omitempty"`
+		Category string `json:"category,omitempty"`
+		Alias
+	}{Alert: h.Alert, Category: h.Category, Alias: Alias(h)})
+}
+
+func checkHMOS(h *HMOS) (*HMOS, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/hmos"
+)
+
+// 获取校验错误中的所有字段路径。
+func invalidFields(t *testing.T, err error) map[string]bool {
+	t.Helper()
+	var errs api.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	fields := make(map[string]bool, len(errs))
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	return fields
+}
+
+func TestHMOSConstructors(t *testing.T) {
+	n, err := notification.NewHMOSVoIPCall(map[string]string{"caller": "alice"})
+	if err != nil || n.PushType != hmos.PushTypeVoIPCall || n.ExtraData != `{"caller":"alice"}` {
+		t.Fatalf("NewHMOSVoIPCall() = %+v, %v", n, err)
+	}
+	// 应用内通话消息不以空字符串下发 alert 和 category。
+	data, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["alert"]; ok {
+		t.Errorf("expected no alert for VoIP call, got %s", data)
+	}
+	if _, ok := m["category"]; ok {
+		t.Errorf("expected no category for VoIP call, got %s", data)
+	}
+	if m["push_type"] != float64(hmos.PushTypeVoIPCall) || m["extra_data"] != `{"caller":"alice"}` {
+		t.Errorf("unexpected VoIP call payload %s", data)
+	}
+
+	n, err = notification.NewHMOSExtension("hi", "IM", map[string]int{"unread": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err = json.Marshal(n); err != nil {
+		t.Fatal(err)
+	}
+	m = nil
+	if err = json.Unmarshal(data, &m); err != nil || m["alert"] != "hi" || m["category"] != "IM" {
+		t.Errorf("expected alert and category in the extension payload, got %s", data)
+	}
+
+	if fields := invalidFields(t, func() error { _, err := notification.NewHMOSAlert("hi", ""); return err }()); !fields["category"] {
+		t.Errorf("expected an error on category, got %v", fields)
+	}
+	if _, err = notification.NewHMOSVoIPCall(nil); err == nil {
+		t.Error("expected an error for nil VoIP data")
+	}
+}
+
+func TestHMOSValidate(t *testing.T) {
+	badge := 1
+	tests := []struct {
+		name  string
+		hmos  *notification.HMOS
+		field string
+	}{
+		{"alert without alert", &notification.HMOS{Category: "IM"}, "alert"},
+		{"alert with extra data", &notification.HMOS{Alert: "hi", Category: "IM", ExtraData: `{}`}, "extra_data"},
+		{"extension without extra data", &notification.HMOS{Alert: "hi", Category: "IM", PushType: hmos.PushTypeExtension}, "extra_data"},
+		{"voip with title", &notification.HMOS{PushType: hmos.PushTypeVoIPCall, ExtraData: `{}`, Title: "call"}, "title"},
+		{"voip with alert", &notification.HMOS{PushType: hmos.PushTypeVoIPCall, ExtraData: `{}`, Alert: "call"}, "alert"},
+		{"voip with badge", &notification.HMOS{PushType: hmos.PushTypeVoIPCall, ExtraData: `{}`, BadgeAddNum: &badge}, "badge_add_num"},
+		{"invalid extra data", &notification.HMOS{PushType: hmos.PushTypeVoIPCall, ExtraData: `{`}, "extra_data"},
+		{"unsupported form update", &notification.HMOS{PushType: hmos.PushTypeFormUpdate, ExtraData: `{"formId":1}`}, "push_type"},
+		{"unsupported live view", &notification.HMOS{PushType: hmos.PushTypeLiveView, ExtraData: `{}`}, "push_type"},
+		{"unknown push type", &notification.HMOS{Alert: "hi", Category: "IM", PushType: 5}, "push_type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fields := invalidFields(t, tt.hmos.Validate()); !fields[tt.field] {
+				t.Errorf("expected an error on %q, got %v", tt.field, fields)
+			}
+		})
+	}
+}
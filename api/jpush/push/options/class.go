@@ -0,0 +1,117 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+// # 业务消息类别
+//
+// 用于按业务语义描述一条推送，由 ClassPolicy 映射为 Options.Classification、各厂商通道的 Category、Importance、ChannelID，
+// 以及 Android、HarmonyOS 通知的 Category 等取值，避免在每次推送时手动设置这些容易出错的字段。
+type MessageClass string
+
+const (
+	ClassTransactional MessageClass = "transactional" // 交易类消息，如订单、物流、账号与资产变动等
+	ClassSocial        MessageClass = "social"        // 社交通讯类消息，如即时聊天、私信、评论回复等
+	ClassReminder      MessageClass = "reminder"      // 提醒类消息，如日程待办、订阅提醒、设备提醒等
+	ClassMarketing     MessageClass = "marketing"     // 营销类消息，如运营活动、内容推荐、新闻资讯等
+)
+
+// # 业务消息类别的推送配置
+//
+// 描述一个业务消息类别对应的完整推送配置，应用时只会填充推送参数中未设置的字段，开发者显式设置的值优先。
+type ClassProfile struct {
+	// 【必填】消息类型分类，对应 Options.Classification。
+	Classification Classification
+	// 【可选】各厂商通道的策略和属性参数，对应 Options.ThirdPartyChannel。
+	ThirdPartyChannel ThirdPartyChannel
+	// 【可选】Android 通知的 Category，对应 notification.Android.Category。
+	AndroidCategory string
+	// 【可选】Android 通知的 ChannelID，对应 notification.Android.ChannelID，需要与客户端创建的通知渠道一致。
+	AndroidChannelID string
+	// 【可选】HarmonyOS 通知的 Category，对应 notification.HMOS.Category。
+	HMOSCategory string
+}
+
+// # 业务消息类别策略
+//
+// 业务消息类别到推送配置的映射，可基于 DefaultClassPolicy 按应用覆盖部分类别，或完全自定义。
+type ClassPolicy map[MessageClass]ClassProfile
+
+// 返回默认的业务消息类别策略，每次调用都返回新的副本，修改后不影响其他调用方。
+//   - 默认策略不设置 ChannelID，各厂商的通知渠道需要由开发者向厂商申请后，在覆盖的策略中自行设置；
+//   - 默认策略按照各厂商的消息分类标准选择 Category 和 Importance，详见 ThirdPartyChannelOptions 的字段说明。
+func DefaultClassPolicy() ClassPolicy {
+	return ClassPolicy{
+		ClassTransactional: {
+			Classification: ClassificationSystem,
+			ThirdPartyChannel: ThirdPartyChannel{
+				Huawei: NewHuaweiOptions(HuaweiCategoryExpress, HuaweiImportanceNormal),
+				Honor:  NewHonorOptions(HonorImportanceNormal),
+				OPPO:   NewOPPOOptions("", OPPOCategoryOrder, 0),
+				Vivo:   NewVivoOptions(VivoCategoryOrder),
+				NIO:    NewNIOOptions(ClassificationSystem),
+			},
+			AndroidCategory: "EXPRESS",
+			HMOSCategory:    "EXPRESS",
+		},
+		ClassSocial: {
+			Classification: ClassificationSystem,
+			ThirdPartyChannel: ThirdPartyChannel{
+				Huawei: NewHuaweiOptions(HuaweiCategoryIM, HuaweiImportanceNormal),
+				Honor:  NewHonorOptions(HonorImportanceNormal),
+				OPPO:   NewOPPOOptions("", OPPOCategoryIM, 0),
+				Vivo:   NewVivoOptions(VivoCategoryIM),
+				NIO:    NewNIOOptions(ClassificationSystem),
+			},
+			AndroidCategory: "IM",
+			HMOSCategory:    "IM",
+		},
+		ClassReminder: {
+			Classification: ClassificationSystem,
+			ThirdPartyChannel: ThirdPartyChannel{
+				Huawei: NewHuaweiOptions(HuaweiCategoryWork, HuaweiImportanceNormal),
+				Honor:  NewHonorOptions(HonorImportanceNormal),
+				OPPO:   NewOPPOOptions("", OPPOCategoryTodo, 0),
+				Vivo:   NewVivoOptions(VivoCategoryTodo),
+				NIO:    NewNIOOptions(ClassificationSystem),
+			},
+			AndroidCategory: "WORK",
+			HMOSCategory:    "WORK",
+		},
+		ClassMarketing: {
+			Classification: ClassificationMarketing,
+			ThirdPartyChannel: ThirdPartyChannel{
+				Huawei: NewHuaweiOptions(HuaweiCategoryMarketing, HuaweiImportanceLow),
+				Honor:  NewHonorOptions(HonorImportanceLow),
+				OPPO:   NewOPPOOptions("", OPPOCategoryMarketing, 0),
+				Vivo:   NewVivoOptions(VivoCategoryMarketing),
+				NIO:    NewNIOOptions(ClassificationMarketing),
+			},
+			AndroidCategory: "MARKETING",
+			HMOSCategory:    "MARKETING",
+		},
+	}
+}
+
+// 返回在当前策略基础上覆盖了部分类别的新策略，当前策略保持不变。
+func (p ClassPolicy) With(overrides ClassPolicy) ClassPolicy {
+	merged := make(ClassPolicy, len(p)+len(overrides))
+	for class, profile := range p {
+		merged[class] = profile
+	}
+	for class, profile := range overrides {
+		merged[class] = profile
+	}
+	return merged
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"slices"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// 厂商通道名称，与 ThirdPartyChannel 的 JSON 字段名一致。
+const (
+	vendorXiaomi = "xiaomi"
+	vendorHuawei = "huawei"
+	vendorHonor  = "honor"
+	vendorMeizu  = "meizu"
+	vendorOPPO   = "oppo"
+	vendorVivo   = "vivo"
+	vendorFCM    = "fcm"
+	vendorNIO    = "nio"
+)
+
+var (
+	distributions          = []string{"first_ospush", "ospush", "jpush", "secondary_push"}
+	distributionFcms       = []string{"jpush", "fcm", "pns", "secondary_fcm_push", "secondary_pns_push"}
+	distributionCustomizes = []string{"jpush", "first_ospush", "secondary_push"}
+
+	huaweiCategories = []HuaweiCategory{
+		HuaweiCategoryIM, HuaweiCategoryVoIP, HuaweiCategorySubscription, HuaweiCategoryTravel, HuaweiCategoryHealth,
+		HuaweiCategoryWork, HuaweiCategoryAccount, HuaweiCategoryExpress, HuaweiCategoryFinance, HuaweiCategoryDeviceReminder,
+		HuaweiCategorySystemReminder, HuaweiCategoryMail, HuaweiCategoryPlayVoice, HuaweiCategoryMarketing,
+	}
+	vivoCategories = []VivoCategory{
+		VivoCategoryIM, VivoCategoryAccount, VivoCategoryTodo, VivoCategoryDeviceReminder, VivoCategoryOrder,
+		VivoCategorySubscription, VivoCategoryNews, VivoCategoryContent, VivoCategoryMarketing, VivoCategorySocial,
+	}
+	oppoCategories = []OPPOCategory{
+		OPPOCategoryIM, OPPOCategoryAccount, OPPOCategoryDeviceReminder, OPPOCategoryOrder, OPPOCategoryTodo,
+		OPPOCategorySubscription, OPPOCategoryNews, OPPOCategoryContent, OPPOCategoryMarketing, OPPOCategorySocial,
+	}
+)
+
+// 在本地按照 JPush 及各厂商文档中的约束校验推送可选项，无需发起网络请求。
+//
+// 校验通过时返回 nil，否则返回包含所有字段错误的 api.ValidationErrors，字段路径与 JSON 字段名一致，如 "third_party_channel.huawei.importance"，主要检查：
+//   - TimeToLive、BigPushDuration、Classification、ApnsCollapseID、Notification3rdVer 等取值在有效范围内；
+//   - ThirdPartyChannel 中各厂商的下发策略、Importance、Category、NotifyLevel、Urgency、PushMode 等取值是否为该厂商支持的取值，
+//     以及是否设置了该厂商不支持的字段；
+//   - 华为的 Category 与 Importance、vivo 的 Category 与 Classification 是否匹配。
+func (o *Options) Validate() error {
+	if o == nil {
+		return nil
+	}
+	var errs api.ValidationErrors
+	if o.TimeToLive != nil && (*o.TimeToLive < 0 || *o.TimeToLive > 864000) {
+		errs.Add("time_to_live", "must be between 0 and 864000 seconds (10 days), got %d", *o.TimeToLive)
+	}
+	if o.BigPushDuration < 0 || o.BigPushDuration > 1400 {
+		errs.Add("big_push_duration", "must be between 0 and 1400 minutes, got %d", o.BigPushDuration)
+	}
+	if o.Classification != nil && !validClassification(*o.Classification) {
+		errs.Add("classification", "must be 0 or 1, got %d", *o.Classification)
+	}
+	if len(o.ApnsCollapseID) > 64 {
+		errs.Add("apns_collapse_id", "cannot be longer than 64 bytes")
+	}
+	switch o.Notification3rdVer {
+	case "", "v1", "v2":
+	default:
+		errs.Add("notification_3rd_ver", "must be v1 or v2, got %q", o.Notification3rdVer)
+	}
+	if o.NeedBackup != nil && *o.NeedBackup && (o.ActivePush == nil || !*o.ActivePush) {
+		errs.Add("need_backup", "requires active_push to be true")
+	}
+
+	if c := o.ThirdPartyChannel; c != nil {
+		validateVendor(&errs, vendorXiaomi, c.Xiaomi, nil)
+		validateVendor(&errs, vendorHuawei, c.Huawei, nil)
+		validateVendor(&errs, vendorHonor, c.Honor, nil)
+		validateVendor(&errs, vendorMeizu, c.Meizu, nil)
+		validateVendor(&errs, vendorOPPO, c.OPPO, nil)
+		validateVendor(&errs, vendorVivo, c.Vivo, o.Classification)
+		validateVendor(&errs, vendorFCM, c.FCM, nil)
+		validateVendor(&errs, vendorNIO, c.NIO, nil)
+	}
+	return errs.Err()
+}
+
+// 校验单个厂商通道的策略和属性参数，`classification` 为 Options 的 Classification，优先级高于厂商的 Classification。
+func validateVendor(errs *api.ValidationErrors, vendor string, c *ThirdPartyChannelOptions, classification *int) {
+	if c == nil {
+		return
+	}
+	field := func(name string) string {
+		return "third_party_channel." + vendor + "." + name
+	}
+	unsupported := func(name string, supported ...string) {
+		if !slices.Contains(supported, vendor) {
+			errs.Add(field(name), "is not supported by %s", vendor)
+		}
+	}
+
+	if c.Distribution != "" && !slices.Contains(distributions, c.Distribution) {
+		errs.Add(field("distribution"), "must be one of %v, got %q", distributions, c.Distribution)
+	}
+	if c.DistributionFcm != "" && !slices.Contains(distributionFcms, c.DistributionFcm) {
+		errs.Add(field("distribution_fcm"), "must be one of %v, got %q", distributionFcms, c.DistributionFcm)
+	}
+	if c.DistributionCustomize != "" {
+		unsupported("distribution_customize", vendorHuawei, vendorHonor)
+		if !slices.Contains(distributionCustomizes, c.DistributionCustomize) {
+			errs.Add(field("distribution_customize"), "must be one of %v, got %q", distributionCustomizes, c.DistributionCustomize)
+		}
+	}
+	if c.Classification != nil && !validClassification(*c.Classification) {
+		errs.Add(field("classification"), "must be 0 or 1, got %d", *c.Classification)
+	}
+	if c.PushMode != nil {
+		unsupported("push_mode", vendorVivo)
+		if *c.PushMode != 0 && *c.PushMode != 1 {
+			errs.Add(field("push_mode"), "must be 0 or 1, got %d", *c.PushMode)
+		}
+	}
+	if c.TargetUserType != nil {
+		unsupported("target_user_type", vendorHuawei)
+		if *c.TargetUserType != 0 && *c.TargetUserType != 1 {
+			errs.Add(field("target_user_type"), "must be 0 or 1, got %d", *c.TargetUserType)
+		}
+	}
+	if c.Urgency != "" {
+		unsupported("urgency", vendorHuawei)
+		if u := HuaweiUrgency(c.Urgency); u != HuaweiUrgencyHigh && u != HuaweiUrgencyNormal {
+			errs.Add(field("urgency"), "must be HIGH or NORMAL, got %q", c.Urgency)
+		}
+	}
+
+	switch vendor {
+	case vendorHuawei:
+		importance, category := HuaweiImportance(c.Importance), HuaweiCategory(c.Category)
+		if c.Importance != "" && importance != HuaweiImportanceLow && importance != HuaweiImportanceNormal && importance != HuaweiImportanceHigh {
+			errs.Add(field("importance"), "must be LOW, NORMAL or HIGH, got %q", c.Importance)
+		}
+		if c.Category != "" && !slices.Contains(huaweiCategories, category) {
+			errs.Add(field("category"), "unsupported huawei category %q", c.Category)
+		}
+		if c.Category != "" && c.Importance != "" {
+			if category == HuaweiCategoryMarketing && importance != HuaweiImportanceLow {
+				errs.Add(field("importance"), "must be LOW for category MARKETING, got %q", c.Importance)
+			} else if category != HuaweiCategoryMarketing && importance == HuaweiImportanceLow {
+				errs.Add(field("importance"), "must be NORMAL or HIGH for category %s, got LOW", c.Category)
+			}
+		}
+	case vendorHonor:
+		if importance := HonorImportance(c.Importance); c.Importance != "" && importance != HonorImportanceLow && importance != HonorImportanceNormal {
+			errs.Add(field("importance"), "must be LOW or NORMAL, got %q", c.Importance)
+		}
+		if c.Category != "" {
+			unsupported("category")
+		}
+	case vendorVivo:
+		category := VivoCategory(c.Category)
+		if c.Category != "" && !slices.Contains(vivoCategories, category) {
+			errs.Add(field("category"), "unsupported vivo category %q", c.Category)
+		} else if c.Category != "" {
+			if classification == nil {
+				classification = c.Classification
+			}
+			if want := category.Classification(); classification != nil && *classification != int(want) {
+				errs.Add(field("category"), "category %s requires classification %d, got %d", c.Category, want, *classification)
+			}
+		}
+	case vendorOPPO:
+		category := OPPOCategory(c.Category)
+		if c.Category != "" && !slices.Contains(oppoCategories, category) {
+			errs.Add(field("category"), "unsupported oppo category %q", c.Category)
+		}
+		if c.NotifyLevel != 0 {
+			switch level := OPPONotifyLevel(c.NotifyLevel); {
+			case level != OPPONotifyLevelBar && level != OPPONotifyLevelLockBar && level != OPPONotifyLevelAllAlerts:
+				errs.Add(field("notify_level"), "must be 1, 2 or 16, got %d", c.NotifyLevel)
+			case c.Category == "":
+				errs.Add(field("notify_level"), "requires category")
+			case !category.IsService():
+				errs.Add(field("notify_level"), "applies only to service categories, got %s", c.Category)
+			}
+		}
+	default:
+		if c.Category != "" {
+			unsupported("category")
+		}
+	}
+	if c.Importance != "" {
+		unsupported("importance", vendorHuawei, vendorHonor)
+	}
+	if c.NotifyLevel != 0 {
+		unsupported("notify_level", vendorOPPO)
+	}
+}
+
+func validClassification(c int) bool {
+	return c == int(ClassificationMarketing) || c == int(ClassificationSystem)
+}
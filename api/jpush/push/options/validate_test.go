@@ -0,0 +1,98 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+func TestValidateVendors(t *testing.T) {
+	valid := &options.Options{
+		ThirdPartyChannel: &options.ThirdPartyChannel{
+			Xiaomi: options.NewXiaomiOptions("high_system"),
+			Huawei: options.NewHuaweiOptions(options.HuaweiCategoryIM, options.HuaweiImportanceNormal),
+			Honor:  options.NewHonorOptions(options.HonorImportanceNormal),
+			OPPO:   options.NewOPPOOptions("im", options.OPPOCategoryIM, options.OPPONotifyLevelAllAlerts),
+			Vivo:   options.NewVivoOptions(options.VivoCategoryMarketing),
+			Meizu:  options.NewMeizuOptions(options.DistributionSecondaryPush),
+			FCM:    options.NewFCMOptions(options.DistributionFcmSecondaryFCMPush),
+			NIO:    options.NewNIOOptions(options.ClassificationSystem),
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid options, got %v", err)
+	}
+
+	two, system := 2, options.ClassificationSystem.Ptr()
+	tests := []struct {
+		name    string
+		channel *options.ThirdPartyChannel
+		field   string
+	}{
+		{"honor high importance", &options.ThirdPartyChannel{Honor: &options.ThirdPartyChannelOptions{Importance: "HIGH"}}, "third_party_channel.honor.importance"},
+		{"huawei marketing normal", &options.ThirdPartyChannel{Huawei: options.NewHuaweiOptions(options.HuaweiCategoryMarketing, options.HuaweiImportanceNormal)}, "third_party_channel.huawei.importance"},
+		{"huawei unknown category", &options.ThirdPartyChannel{Huawei: &options.ThirdPartyChannelOptions{Category: "ORDER"}}, "third_party_channel.huawei.category"},
+		{"xiaomi importance", &options.ThirdPartyChannel{Xiaomi: &options.ThirdPartyChannelOptions{Importance: "LOW"}}, "third_party_channel.xiaomi.importance"},
+		{"oppo notify level", &options.ThirdPartyChannel{OPPO: &options.ThirdPartyChannelOptions{Category: "IM", NotifyLevel: 3}}, "third_party_channel.oppo.notify_level"},
+		{"oppo marketing notify level", &options.ThirdPartyChannel{OPPO: options.NewOPPOOptions("", options.OPPOCategoryMarketing, options.OPPONotifyLevelBar)}, "third_party_channel.oppo.notify_level"},
+		{"vivo mismatched classification", &options.ThirdPartyChannel{Vivo: &options.ThirdPartyChannelOptions{Category: "NEWS", Classification: system}}, "third_party_channel.vivo.category"},
+		{"meizu push mode", &options.ThirdPartyChannel{Meizu: &options.ThirdPartyChannelOptions{PushMode: &two}}, "third_party_channel.meizu.push_mode"},
+		{"fcm urgency", &options.ThirdPartyChannel{FCM: &options.ThirdPartyChannelOptions{Urgency: "HIGH"}}, "third_party_channel.fcm.urgency"},
+		{"bad distribution", &options.ThirdPartyChannel{Xiaomi: &options.ThirdPartyChannelOptions{Distribution: "ospush_first"}}, "third_party_channel.xiaomi.distribution"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs api.ValidationErrors
+			if !errors.As((&options.Options{ThirdPartyChannel: tt.channel}).Validate(), &errs) {
+				t.Fatal("expected ValidationErrors")
+			}
+			for _, e := range errs {
+				if e.Field == tt.field {
+					return
+				}
+			}
+			t.Errorf("expected an error on %q, got %v", tt.field, errs)
+		})
+	}
+}
+
+func TestValidateVivoClassificationOverride(t *testing.T) {
+	// Options 的 Classification 优先级高于厂商的 Classification。
+	o := &options.Options{
+		Classification:    options.ClassificationMarketing.Ptr(),
+		ThirdPartyChannel: &options.ThirdPartyChannel{Vivo: options.NewVivoOptions(options.VivoCategoryIM)},
+	}
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error when options classification overrides vivo category")
+	}
+	o.Classification = options.ClassificationSystem.Ptr()
+	if err := o.Validate(); err != nil {
+		t.Errorf("expected valid options, got %v", err)
+	}
+}
+
+func TestDefaultClassPolicyIsValid(t *testing.T) {
+	for class, profile := range options.DefaultClassPolicy() {
+		channel := profile.ThirdPartyChannel
+		o := &options.Options{Classification: profile.Classification.Ptr(), ThirdPartyChannel: &channel}
+		if err := o.Validate(); err != nil {
+			t.Errorf("%s: expected a valid profile, got %v", class, err)
+		}
+	}
+}
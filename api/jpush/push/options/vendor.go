@@ -0,0 +1,223 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+// # 消息类型分类
+//
+// 对应 Options 和 ThirdPartyChannelOptions 的 Classification 字段，用于适配 vivo、蔚来等厂商的系统消息和运营消息分类。
+type Classification int
+
+const (
+	ClassificationMarketing Classification = 0 // 运营消息
+	ClassificationSystem    Classification = 1 // 系统消息
+)
+
+// 返回 Classification 的指针，用于设置 Options 和 ThirdPartyChannelOptions 的 Classification 字段。
+func (c Classification) Ptr() *int {
+	v := int(c)
+	return &v
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 华为「云端通知」消息场景标识
+//
+// 详见 [华为云端通知 category 取值] 文档说明。
+//
+// [华为云端通知 category 取值]: https://developer.huawei.com/consumer/cn/doc/development/HMSCore-Guides/message-classification-0000001149358835#section153801515616
+type HuaweiCategory string
+
+const (
+	HuaweiCategoryIM             HuaweiCategory = "IM"              // 即时聊天
+	HuaweiCategoryVoIP           HuaweiCategory = "VOIP"            // 音视频通话
+	HuaweiCategorySubscription   HuaweiCategory = "SUBSCRIPTION"    // 订阅
+	HuaweiCategoryTravel         HuaweiCategory = "TRAVEL"          // 出行
+	HuaweiCategoryHealth         HuaweiCategory = "HEALTH"          // 健康
+	HuaweiCategoryWork           HuaweiCategory = "WORK"            // 工作事项提醒
+	HuaweiCategoryAccount        HuaweiCategory = "ACCOUNT"         // 账号动态
+	HuaweiCategoryExpress        HuaweiCategory = "EXPRESS"         // 订单 & 物流
+	HuaweiCategoryFinance        HuaweiCategory = "FINANCE"         // 财务
+	HuaweiCategoryDeviceReminder HuaweiCategory = "DEVICE_REMINDER" // 设备提醒
+	HuaweiCategorySystemReminder HuaweiCategory = "SYSTEM_REMINDER" // 系统提示
+	HuaweiCategoryMail           HuaweiCategory = "MAIL"            // 邮件
+	HuaweiCategoryPlayVoice      HuaweiCategory = "PLAY_VOICE"      // 语音播报
+	HuaweiCategoryMarketing      HuaweiCategory = "MARKETING"       // 内容推荐、新闻、财经动态、生活资讯、社交动态、调研、产品促销、功能推荐、运营活动
+)
+
+// 华为「云端通知」消息智能分类（importance）。
+type HuaweiImportance string
+
+const (
+	HuaweiImportanceLow    HuaweiImportance = "LOW"    // 一般消息（资讯营销类）
+	HuaweiImportanceNormal HuaweiImportance = "NORMAL" // 重要消息（服务与通讯类）
+	HuaweiImportanceHigh   HuaweiImportance = "HIGH"   // 非常重要消息
+)
+
+// 华为厂商自定义消息优先级（urgency）。
+type HuaweiUrgency string
+
+const (
+	HuaweiUrgencyHigh   HuaweiUrgency = "HIGH"   // 非常重要消息，可强制拉起应用进程，需要向华为申请特殊权限
+	HuaweiUrgencyNormal HuaweiUrgency = "NORMAL" // 重要消息
+)
+
+// 荣耀通知消息分类（importance），荣耀不支持 HIGH。
+type HonorImportance string
+
+const (
+	HonorImportanceLow    HonorImportance = "LOW"    // 资讯营销类消息
+	HonorImportanceNormal HonorImportance = "NORMAL" // 服务与通讯类消息
+)
+
+// # vivo 消息二级分类
+//
+// 详见 [vivo 官方说明] 文档说明，前 6 个为系统消息，其余为运营消息。
+//
+// [vivo 官方说明]: https://dev.vivo.com.cn/documentCenter/doc/359#w1-36109489
+type VivoCategory string
+
+const (
+	VivoCategoryIM             VivoCategory = "IM"              // 即时消息（系统消息）
+	VivoCategoryAccount        VivoCategory = "ACCOUNT"         // 账号与资产（系统消息）
+	VivoCategoryTodo           VivoCategory = "TODO"            // 日程待办（系统消息）
+	VivoCategoryDeviceReminder VivoCategory = "DEVICE_REMINDER" // 设备信息（系统消息）
+	VivoCategoryOrder          VivoCategory = "ORDER"           // 订单与物流（系统消息）
+	VivoCategorySubscription   VivoCategory = "SUBSCRIPTION"    // 订阅提醒（系统消息）
+	VivoCategoryNews           VivoCategory = "NEWS"            // 新闻（运营消息）
+	VivoCategoryContent        VivoCategory = "CONTENT"         // 内容推荐（运营消息）
+	VivoCategoryMarketing      VivoCategory = "MARKETING"       // 运营活动（运营消息）
+	VivoCategorySocial         VivoCategory = "SOCIAL"          // 社交动态（运营消息）
+)
+
+// 返回 vivo 消息二级分类对应的消息类型分类。
+func (c VivoCategory) Classification() Classification {
+	switch c {
+	case VivoCategoryNews, VivoCategoryContent, VivoCategoryMarketing, VivoCategorySocial:
+		return ClassificationMarketing
+	default:
+		return ClassificationSystem
+	}
+}
+
+// # OPPO 消息分类
+//
+// OPPO 于 2024.11.20 实施消息分类新规，前 6 个为通讯与服务类消息，其余为内容与营销类消息，详见 [OPPO 官方说明] 文档说明。
+//
+// [OPPO 官方说明]: https://open.oppomobile.com/new/developmentDoc/info?id=13189
+type OPPOCategory string
+
+const (
+	OPPOCategoryIM             OPPOCategory = "IM"              // 即时聊天（通讯与服务）
+	OPPOCategoryAccount        OPPOCategory = "ACCOUNT"         // 账号与资产（通讯与服务）
+	OPPOCategoryDeviceReminder OPPOCategory = "DEVICE_REMINDER" // 设备提醒（通讯与服务）
+	OPPOCategoryOrder          OPPOCategory = "ORDER"           // 订单与物流（通讯与服务）
+	OPPOCategoryTodo           OPPOCategory = "TODO"            // 日程待办（通讯与服务）
+	OPPOCategorySubscription   OPPOCategory = "SUBSCRIPTION"    // 订阅提醒（通讯与服务）
+	OPPOCategoryNews           OPPOCategory = "NEWS"            // 新闻资讯（内容与营销）
+	OPPOCategoryContent        OPPOCategory = "CONTENT"         // 内容推荐（内容与营销）
+	OPPOCategoryMarketing      OPPOCategory = "MARKETING"       // 运营活动（内容与营销）
+	OPPOCategorySocial         OPPOCategory = "SOCIAL"          // 社交动态（内容与营销）
+)
+
+// 判断是否为通讯与服务类消息，只有此类消息支持 NotifyLevel。
+func (c OPPOCategory) IsService() bool {
+	switch c {
+	case OPPOCategoryIM, OPPOCategoryAccount, OPPOCategoryDeviceReminder, OPPOCategoryOrder, OPPOCategoryTodo, OPPOCategorySubscription:
+		return true
+	default:
+		return false
+	}
+}
+
+// OPPO 通知栏消息提醒等级。
+type OPPONotifyLevel int
+
+const (
+	OPPONotifyLevelBar       OPPONotifyLevel = 1  // 通知栏
+	OPPONotifyLevelLockBar   OPPONotifyLevel = 2  // 通知栏 + 锁屏
+	OPPONotifyLevelAllAlerts OPPONotifyLevel = 16 // 通知栏 + 锁屏 + 横幅 + 震动 + 铃声
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 通知栏消息下发逻辑，对应 ThirdPartyChannelOptions 的 Distribution 字段。
+type Distribution string
+
+const (
+	DistributionFirstOSPush   Distribution = "first_ospush"   // 成功注册厂商通道的设备走厂商通道，仅注册极光通道的设备走极光通道（VIP）
+	DistributionOSPush        Distribution = "ospush"         // 强制走厂商通道（VIP）
+	DistributionJPush         Distribution = "jpush"          // 强制走极光通道
+	DistributionSecondaryPush Distribution = "secondary_push" // 优先走极光，极光不在线再走厂商，厂商作为辅助
+)
+
+// 通知栏消息 FCM + 国内厂商组合类型下发逻辑，对应 ThirdPartyChannelOptions 的 DistributionFcm 字段。
+type DistributionFcm string
+
+const (
+	DistributionFcmJPush            DistributionFcm = "jpush"              // 强制走极光通道
+	DistributionFcmFCM              DistributionFcm = "fcm"                // 强制走 FCM 通道（VIP）
+	DistributionFcmPNS              DistributionFcm = "pns"                // 强制走国内厂商通道（VIP）
+	DistributionFcmSecondaryFCMPush DistributionFcm = "secondary_fcm_push" // 优先走极光，极光不在线再走 FCM 通道
+	DistributionFcmSecondaryPNSPush DistributionFcm = "secondary_pns_push" // 优先走极光，极光不在线再走国内厂商通道
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 创建小米通道的策略和属性参数，`channelID` 为向小米申请的通知渠道 ID。
+func NewXiaomiOptions(channelID string) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{ChannelID: channelID}
+}
+
+// 创建华为通道的策略和属性参数，`category` 与 `importance` 需要符合《华为消息分类标准》的对应关系，如 MARKETING 只能使用 LOW。
+func NewHuaweiOptions(category HuaweiCategory, importance HuaweiImportance) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{Category: string(category), Importance: string(importance)}
+}
+
+// 创建华为通道自定义消息的策略和属性参数，`urgency` 为自定义消息优先级。
+func NewHuaweiMessageOptions(urgency HuaweiUrgency) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{Urgency: string(urgency)}
+}
+
+// 创建荣耀通道的策略和属性参数。
+func NewHonorOptions(importance HonorImportance) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{Importance: string(importance)}
+}
+
+// 创建 OPPO 通道的策略和属性参数，`notifyLevel` 仅对通讯与服务类消息生效，为 0 时不设置。
+func NewOPPOOptions(channelID string, category OPPOCategory, notifyLevel OPPONotifyLevel) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{ChannelID: channelID, Category: string(category), NotifyLevel: int(notifyLevel)}
+}
+
+// 创建 vivo 通道的策略和属性参数，Classification 会根据 `category` 自动设置为对应的系统消息或运营消息。
+func NewVivoOptions(category VivoCategory) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{Category: string(category), Classification: category.Classification().Ptr()}
+}
+
+// 创建蔚来通道的策略和属性参数，蔚来根据 `classification` 确定发送给厂商的 Category。
+func NewNIOOptions(classification Classification) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{Classification: classification.Ptr()}
+}
+
+// 创建魅族通道的策略和属性参数，魅族没有消息分类相关的参数，只能指定通知栏消息下发逻辑 `distribution`。
+func NewMeizuOptions(distribution Distribution) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{Distribution: string(distribution)}
+}
+
+// 创建 FCM 通道的策略和属性参数，`distributionFcm` 为 FCM + 国内厂商组合类型下发逻辑。
+//   - FCM 的消息优先级由 Android 通知的 Priority 决定：-2～-1 对应 FCM 的 normal，0～2 对应 FCM 的 high；
+//   - 通知的重要性由 Android 通知的 ChannelID 对应的通知渠道决定，不在厂商通道参数中设置。
+func NewFCMOptions(distributionFcm DistributionFcm) *ThirdPartyChannelOptions {
+	return &ThirdPartyChannelOptions{DistributionFcm: string(distributionFcm)}
+}
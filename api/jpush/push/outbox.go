@@ -0,0 +1,423 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// # 持久化推送发件箱
+//
+// 在发送推送之前，先将推送参数写入本地磁盘上的预写日志（WAL），只有在收到推送结果后才将其标记为已完成，
+// 从而保证进程在推送过程中崩溃时，推送不会丢失：
+//   - 每条推送在写入日志前都会被预先分配 CID（通过 GetCidForPush 获取），重新发送时由服务端按 CID 去重，因此不会重复推送；
+//   - 进程重启后，调用 Replay 重新发送日志中尚未完成的推送；
+//   - 日志中已完成的记录会被定期压缩清理。
+//
+// 可被多个 goroutine 并发使用，但同一个日志文件只能被一个 Outbox 打开。
+//
+//	outbox, err := push.OpenOutbox("/var/lib/myapp/push.wal", pushAPIv3)
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer outbox.Close()
+//
+//	// 启动时重新发送上次未完成的推送
+//	if _, err = outbox.Replay(ctx); err != nil {
+//		log.Printf("replay outbox: %v", err)
+//	}
+//
+//	result, err := outbox.Send(ctx, param)
+type Outbox struct {
+	api          APIv3
+	path         string
+	cidBatchSize int
+	compactAfter int
+
+	mu      sync.Mutex
+	f       *os.File
+	pending map[string]json.RawMessage // CID -> 推送参数
+	order   []string                   // 尚未完成的推送的 CID，按写入的顺序排列
+	done    int                        // 上次压缩以来已完成的记录数
+
+	cidMu sync.Mutex
+	cids  []string // 预先获取的 CID 池
+}
+
+// OutboxEntry 是发件箱中一条尚未完成的推送。
+type OutboxEntry struct {
+	CID   string          // 推送唯一标识
+	Param json.RawMessage // 推送参数的 JSON
+}
+
+// 日志记录。
+type outboxRecord struct {
+	Op    string          `json:"op"` // "put" 或 "done"
+	CID   string          `json:"cid"`
+	Param json.RawMessage `json:"param,omitempty"`
+	MsgID string          `json:"msg_id,omitempty"`
+}
+
+const (
+	outboxOpPut  = "put"
+	outboxOpDone = "done"
+)
+
+// 用于配置 Outbox 的选项。
+type OutboxOption func(*Outbox)
+
+// 设置每次调用 GetCidForPush 获取的 CID 数量，默认为 10；CID 的有效期为 1 天，不宜设置过大。
+func WithOutboxCidBatchSize(n int) OutboxOption {
+	return func(o *Outbox) {
+		if n > 0 {
+			o.cidBatchSize = n
+		}
+	}
+}
+
+// 设置在累计多少条已完成的记录后压缩日志，默认为 1000。
+func WithOutboxCompactAfter(n int) OutboxOption {
+	return func(o *Outbox) {
+		if n > 0 {
+			o.compactAfter = n
+		}
+	}
+}
+
+// 打开（或创建）持久化推送发件箱，并加载日志中尚未完成的推送，该方法不会发起任何网络请求。
+//   - 日志末尾因崩溃而写入不完整的记录会被忽略。
+func OpenOutbox(path string, pushAPIv3 APIv3, opts ...OutboxOption) (*Outbox, error) {
+	if pushAPIv3 == nil {
+		return nil, errors.New("`pushAPIv3` cannot be nil")
+	}
+	o := &Outbox{
+		api:          pushAPIv3,
+		path:         path,
+		cidBatchSize: 10,
+		compactAfter: 1000,
+		pending:      make(map[string]json.RawMessage),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if err := o.load(); err != nil {
+		return nil, err
+	}
+	if err := o.compact(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// 读取日志，恢复尚未完成的推送。
+func (o *Outbox) load() error {
+	data, err := os.ReadFile(o.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var rec outboxRecord
+		if err = json.Unmarshal(scanner.Bytes(), &rec); err != nil || rec.CID == "" {
+			continue // 写入不完整的记录
+		}
+		switch rec.Op {
+		case outboxOpPut:
+			if _, ok := o.pending[rec.CID]; !ok {
+				o.order = append(o.order, rec.CID)
+			}
+			o.pending[rec.CID] = rec.Param
+		case outboxOpDone:
+			delete(o.pending, rec.CID)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("read outbox %q: %w", o.path, err)
+	}
+	o.order = o.pendingOrder()
+	return nil
+}
+
+// 按写入顺序排列的尚未完成的推送的 CID，调用前需要持有锁。
+func (o *Outbox) pendingOrder() []string {
+	order := make([]string, 0, len(o.pending))
+	seen := make(map[string]bool, len(o.pending))
+	for _, cid := range o.order {
+		if _, ok := o.pending[cid]; ok && !seen[cid] {
+			order = append(order, cid)
+			seen[cid] = true
+		}
+	}
+	return order
+}
+
+// 压缩日志：将尚未完成的推送写入临时文件后替换原日志，调用前需要持有锁（或处于初始化阶段）。
+func (o *Outbox) compact() error {
+	if o.f != nil {
+		_ = o.f.Close()
+		o.f = nil
+	}
+	o.order = o.pendingOrder()
+
+	tmp := o.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, cid := range o.order {
+		if err = writeOutboxRecord(w, outboxRecord{Op: outboxOpPut, CID: cid, Param: o.pending[cid]}); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err = w.Flush(); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, o.path); err != nil {
+		return err
+	}
+
+	o.f, err = os.OpenFile(o.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	o.done = 0
+	return err
+}
+
+func writeOutboxRecord(w interface{ Write([]byte) (int, error) }, rec outboxRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// 追加一条日志记录并同步到磁盘。
+func (o *Outbox) append(rec outboxRecord) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.f == nil {
+		return errors.New("outbox is closed")
+	}
+	if err := writeOutboxRecord(o.f, rec); err != nil {
+		return err
+	}
+	if err := o.f.Sync(); err != nil {
+		return err
+	}
+
+	switch rec.Op {
+	case outboxOpPut:
+		if _, ok := o.pending[rec.CID]; !ok {
+			o.order = append(o.order, rec.CID)
+		}
+		o.pending[rec.CID] = rec.Param
+	case outboxOpDone:
+		delete(o.pending, rec.CID)
+		if o.done++; o.done >= o.compactAfter {
+			return o.compact()
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 获取一个新的 CID，CID 池为空时通过 GetCidForPush 批量获取。
+func (o *Outbox) nextCID(ctx context.Context) (string, error) {
+	o.cidMu.Lock()
+	defer o.cidMu.Unlock()
+	if len(o.cids) == 0 {
+		result, err := o.api.GetCidForPush(ctx, o.cidBatchSize)
+		if err != nil {
+			return "", err
+		}
+		if !result.IsSuccess() {
+			return "", fmt.Errorf("get cid for push: %d %s", result.StatusCode, result.Error)
+		}
+		if len(result.CidList) == 0 {
+			return "", errors.New("get cid for push: empty cid list")
+		}
+		o.cids = result.CidList
+	}
+	cid := o.cids[0]
+	o.cids = o.cids[1:]
+	return cid, nil
+}
+
+// 持久化并发送推送。
+//   - 写入日志前先应用业务消息类别（Class）并在启用了发送前的本地校验时进行校验，日志中保存的是最终发送的推送参数，
+//     因此 Replay 重新发送的内容与第一次发送的完全一致；
+//   - 如果 param 未设置 CID，则预先分配一个，传入的 param 不会被修改；
+//   - 推送参数在发送前被写入日志并同步到磁盘，写入失败时不会发送；
+//   - 收到推送结果后（包括业务错误，如 1011 没有满足条件的推送目标），推送被标记为已完成；
+//     网络错误、5xx 或 429 等可重试的失败不会被标记为已完成，而是留待 Replay 重新发送。
+func (o *Outbox) Send(ctx context.Context, param *SendParam) (*SendResult, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	prepared, err := o.prepare(param)
+	if err != nil {
+		return nil, err
+	}
+	p := *prepared
+	if p.CID == "" {
+		cid, err := o.nextCID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.CID = cid
+	}
+	data, err := json.Marshal(&p)
+	if err != nil {
+		return nil, err
+	}
+	if err = o.append(outboxRecord{Op: outboxOpPut, CID: p.CID, Param: data}); err != nil {
+		return nil, fmt.Errorf("write outbox: %w", err)
+	}
+	return o.send(ctx, p.CID, data)
+}
+
+// 发送前准备推送参数，由 NewAPIv3Builder 创建的 APIv3 提供。
+type sendParamPreparer interface {
+	prepare(param *SendParam) (*SendParam, error)
+}
+
+// 应用业务消息类别，并在启用了发送前的本地校验时进行校验。
+//   - 底层 APIv3 不是由 NewAPIv3Builder 创建的（如测试替身）时，使用默认的业务消息类别策略，且不进行本地校验。
+func (o *Outbox) prepare(param *SendParam) (*SendParam, error) {
+	if pp, ok := o.api.(sendParamPreparer); ok {
+		return pp.prepare(param)
+	}
+	return param.ApplyClass(nil)
+}
+
+func (o *Outbox) send(ctx context.Context, cid string, param json.RawMessage) (*SendResult, error) {
+	result, err := o.api.CustomSend(ctx, param)
+	if !outboxSettled(result, err) {
+		return result, err
+	}
+	var msgID string
+	if result != nil {
+		msgID = result.MsgID
+	}
+	if appendErr := o.append(outboxRecord{Op: outboxOpDone, CID: cid, MsgID: msgID}); appendErr != nil && err == nil {
+		err = fmt.Errorf("write outbox: %w", appendErr)
+	}
+	return result, err
+}
+
+// 判断推送是否已有确定的结果，即重新发送也不会改变结果。
+func outboxSettled(result *SendResult, err error) bool {
+	status := 0
+	if result != nil && result.Response != nil {
+		status = result.StatusCode
+	}
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	} else if err != nil {
+		return false
+	}
+	return status != 0 && status != http.StatusTooManyRequests && status < 500
+}
+
+// 重新发送日志中所有尚未完成的推送（通常在启动时调用），返回已完成的推送数量。
+//   - 由于使用与第一次发送相同的 CID，已经成功推送过的不会被重复推送；
+//   - 遇到 ctx 被取消时停止，其他失败不会中断重新发送，所有失败通过 errors.Join 合并返回。
+func (o *Outbox) Replay(ctx context.Context) (int, error) {
+	var (
+		completed int
+		errs      []error
+	)
+	for _, entry := range o.Pending() {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if _, err := o.send(ctx, entry.CID, entry.Param); err != nil {
+			errs = append(errs, fmt.Errorf("replay %s: %w", entry.CID, err))
+		}
+		if o.isDone(entry.CID) {
+			completed++
+		}
+	}
+	return completed, errors.Join(errs...)
+}
+
+func (o *Outbox) isDone(cid string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, ok := o.pending[cid]
+	return !ok
+}
+
+// 获取所有尚未完成的推送，按写入的顺序排列。
+func (o *Outbox) Pending() []OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	entries := make([]OutboxEntry, 0, len(o.pending))
+	for _, cid := range o.pendingOrder() {
+		entries = append(entries, OutboxEntry{CID: cid, Param: o.pending[cid]})
+	}
+	return entries
+}
+
+// 获取一个通过发件箱发送推送的 APIv3，其 Send 方法等同于 Outbox.Send，其他方法直接调用原 APIv3。
+//   - 可用于 NewDispatcher，使异步推送同样具备持久化保证。
+func (o *Outbox) API() APIv3 {
+	return &outboxAPIv3{APIv3: o.api, outbox: o}
+}
+
+type outboxAPIv3 struct {
+	APIv3
+	outbox *Outbox
+}
+
+func (a *outboxAPIv3) Send(ctx context.Context, param *SendParam) (*SendResult, error) {
+	return a.outbox.Send(ctx, param)
+}
+
+// 关闭日志文件，关闭后不能再发送推送。
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.f == nil {
+		return nil
+	}
+	err := o.f.Close()
+	o.f = nil
+	return err
+}
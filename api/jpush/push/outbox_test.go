@@ -0,0 +1,170 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+func TestOutboxSend(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, nil, androidDevice("rid1"))
+	path := filepath.Join(t.TempDir(), "push.wal")
+	ctx := context.Background()
+
+	outbox, err := push.OpenOutbox(path, pushAPIv3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outbox.Close()
+
+	param := newParam("hello")
+	result, err := outbox.Send(ctx, param)
+	if err != nil || !result.IsSuccess() {
+		t.Fatalf("Send() = %+v, %v", result, err)
+	}
+	if param.CID != "" {
+		t.Error("Send() should not modify the param")
+	}
+	pushes := srv.Pushes()
+	if len(pushes) != 1 || !strings.HasPrefix(pushes[0].CID, jiguangtest.DefaultAppKey+"-") {
+		t.Fatalf("Pushes() = %+v, want one push with a pre-assigned cid", pushes)
+	}
+
+	// 业务错误同样是确定的结果，不会被留待重新发送
+	srv.Fail(jiguangtest.Failure{Path: "/v3/push", Code: 1011, Message: "cannot find user by this audience", Times: 1})
+	if result, err = outbox.Send(ctx, param); err != nil || result.Error == nil || result.Error.Code != 1011 {
+		t.Fatalf("Send() = %+v, %v, want error 1011", result, err)
+	}
+	if n := len(outbox.Pending()); n != 0 {
+		t.Errorf("len(Pending()) = %d, want 0", n)
+	}
+}
+
+func TestOutboxReplay(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, nil, androidDevice("rid1"))
+	path := filepath.Join(t.TempDir(), "push.wal")
+	ctx := context.Background()
+
+	outbox, err := push.OpenOutbox(path, pushAPIv3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Fail(jiguangtest.Failure{Path: "/v3/push", StatusCode: http.StatusServiceUnavailable, Times: 1})
+	if _, err = outbox.Send(ctx, newParam("unavailable")); err == nil {
+		t.Fatal("Send() should fail with 503")
+	}
+	sent, err := outbox.Send(ctx, newParam("sent"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = outbox.Close()
+
+	// 模拟在收到推送结果之前崩溃：已成功推送的记录未被标记为已完成，并且日志末尾有一条写入不完整的记录
+	var cid string
+	for _, p := range srv.Pushes() {
+		if p.MsgID == sent.MsgID {
+			cid = p.CID
+		}
+	}
+	put, _ := json.Marshal(map[string]interface{}{"op": "put", "cid": cid, "param": newParamWithCID("sent", cid)})
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.Write(append(put, '\n'))
+	_, _ = f.WriteString(`{"op":"put","cid":"torn`)
+	_ = f.Close()
+
+	outbox, err = push.OpenOutbox(path, pushAPIv3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outbox.Close()
+	if n := len(outbox.Pending()); n != 2 {
+		t.Fatalf("len(Pending()) = %d after restart, want 2", n)
+	}
+	completed, err := outbox.Replay(ctx)
+	if err != nil || completed != 2 {
+		t.Fatalf("Replay() = %d, %v, want 2", completed, err)
+	}
+	if n := len(srv.Pushes()); n != 2 {
+		t.Errorf("len(Pushes()) = %d, want 2 (the replayed cid is de-duplicated)", n)
+	}
+	if n := len(outbox.Pending()); n != 0 {
+		t.Errorf("len(Pending()) = %d after replay, want 0", n)
+	}
+}
+
+func TestOutboxSend_ClassAndValidation(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, func(cfg *sdk.Config) { cfg.ValidateBeforeSend = true }, androidDevice("rid1"))
+	path := filepath.Join(t.TempDir(), "push.wal")
+	ctx := context.Background()
+
+	outbox, err := push.OpenOutbox(path, pushAPIv3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outbox.Close()
+
+	param := newParam("order shipped")
+	param.Class = push.ClassTransactional
+	if result, err := outbox.Send(ctx, param); err != nil || !result.IsSuccess() {
+		t.Fatalf("Send() = %+v, %v", result, err)
+	}
+	reqs := srv.RequestsTo(http.MethodPost, "/v3/push")
+	if len(reqs) != 1 {
+		t.Fatalf("expected 1 push request, got %d", len(reqs))
+	}
+	var body push.SendParam
+	if err = reqs[0].Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Options == nil || body.Options.Classification == nil || *body.Options.Classification != int(options.ClassificationSystem) {
+		t.Fatalf("expected the transactional classification on the wire, got %+v", body.Options)
+	}
+	if huawei := body.Options.ThirdPartyChannel.Huawei; huawei == nil || huawei.Category != string(options.HuaweiCategoryExpress) {
+		t.Errorf("expected the huawei category on the wire, got %+v", huawei)
+	}
+
+	// 校验失败的推送不会被写入日志，也不会被发送。
+	invalid := newParam("")
+	invalid.Notification = nil
+	if _, err = outbox.Send(ctx, invalid); err == nil {
+		t.Fatal("expected a validation error")
+	}
+	if n := len(outbox.Pending()); n != 0 {
+		t.Errorf("len(Pending()) = %d, want 0", n)
+	}
+	if n := len(srv.RequestsTo(http.MethodPost, "/v3/push")); n != 1 {
+		t.Errorf("expected no push request for the invalid param, got %d in total", n)
+	}
+}
+
+func newParamWithCID(alert, cid string) *push.SendParam {
+	param := newParam(alert)
+	param.CID = cid
+	return param
+}
@@ -0,0 +1,320 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+)
+
+// # 推送参数构建器
+//
+// 以类型安全的链式调用构建 SendParam，并在 Build 时校验参数，例如：
+//
+//	param, err := push.New().
+//		ToAliases("alice", "bob").
+//		OnPlatforms(platform.Android, platform.IOS).
+//		Android(&push.AndroidNotification{Alert: "Hi, Android!"}).
+//		IOS(&push.IosNotification{Alert: "Hi, iOS!"}).
+//		TTL(time.Hour).
+//		Build()
+//
+// 注意事项：
+//   - 未指定推送平台时默认为 platform.All；
+//   - 推送目标必须通过 Broadcast 或 To* 系列方法指定其一，广播不能与其他推送目标组合；
+//   - Build 返回的错误为 ValidationErrors，包含构建过程中的组合错误和 SendParam.Validate 的校验错误。
+type ParamBuilder struct {
+	param     SendParam
+	platforms []platform.Platform
+	broadcast bool
+	audience  Audience
+	errs      ValidationErrors
+}
+
+// 创建推送参数构建器。
+func New() *ParamBuilder {
+	return &ParamBuilder{}
+}
+
+func (b *ParamBuilder) fail(field, message string) *ParamBuilder {
+	b.errs = append(b.errs, &ValidationError{Field: field, Message: message})
+	return b
+}
+
+func (b *ParamBuilder) options() *Options {
+	if b.param.Options == nil {
+		b.param.Options = &Options{}
+	}
+	return b.param.Options
+}
+
+func (b *ParamBuilder) notification() *Notification {
+	if b.param.Notification == nil {
+		b.param.Notification = &Notification{}
+	}
+	return b.param.Notification
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 【可选】设置推送唯一标识 (CID)，可通过 GetCidForPush 接口获取。
+func (b *ParamBuilder) CID(cid string) *ParamBuilder {
+	b.param.CID = cid
+	return b
+}
+
+// 【可选】设置推送平台，可多次调用以追加平台，未设置时默认为 platform.All。
+func (b *ParamBuilder) OnPlatforms(plats ...platform.Platform) *ParamBuilder {
+	b.platforms = append(b.platforms, plats...)
+	return b
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 【推送目标】广播推送，给全部设备进行推送，不能与其他推送目标组合。
+func (b *ParamBuilder) Broadcast() *ParamBuilder {
+	b.broadcast = true
+	return b
+}
+
+// 【推送目标】按设备标识 Registration ID 推送，多次调用时追加，最多 1000 个。
+func (b *ParamBuilder) ToRegistrationIDs(registrationIDs ...string) *ParamBuilder {
+	b.audience.RegistrationIDs = append(b.audience.RegistrationIDs, registrationIDs...)
+	return b
+}
+
+// 【推送目标】按标签推送，多个标签之间取并集，多次调用时追加，最多 20 个。
+func (b *ParamBuilder) ToTags(tags ...string) *ParamBuilder {
+	b.audience.Tags = append(b.audience.Tags, tags...)
+	return b
+}
+
+// 【推送目标】按标签推送，多个标签之间取交集，多次调用时追加，最多 20 个。
+func (b *ParamBuilder) ToAndTags(tags ...string) *ParamBuilder {
+	b.audience.AndTags = append(b.audience.AndTags, tags...)
+	return b
+}
+
+// 【推送目标】排除标签，先取多个标签的并集，再对该结果取补集，多次调用时追加，最多 20 个。
+func (b *ParamBuilder) ToNotTags(tags ...string) *ParamBuilder {
+	b.audience.NotTags = append(b.audience.NotTags, tags...)
+	return b
+}
+
+// 【推送目标】按别名推送，多次调用时追加，最多 1000 个。
+func (b *ParamBuilder) ToAliases(aliases ...string) *ParamBuilder {
+	b.audience.Aliases = append(b.audience.Aliases, aliases...)
+	return b
+}
+
+// 【推送目标】按用户分群推送，目前一次只能推送一个分群。
+func (b *ParamBuilder) ToSegment(segmentID string) *ParamBuilder {
+	b.audience.Segments = append(b.audience.Segments, segmentID)
+	return b
+}
+
+// 【推送目标】按 A/B 测试推送，目前一次只能推送一个 A/B 测试。
+func (b *ParamBuilder) ToAbTest(abTestID string) *ParamBuilder {
+	b.audience.AbTests = append(b.audience.AbTests, abTestID)
+	return b
+}
+
+// 【推送目标】按文件推送，不能与其他推送目标组合，用于 SendByFile 和文件定时推送等接口。
+func (b *ParamBuilder) ToFile(fileID string) *ParamBuilder {
+	b.audience.File = &FileAudience{FileID: fileID}
+	return b
+}
+
+// 【推送目标】按实时活动标识推送，不能与其他推送目标组合。
+func (b *ParamBuilder) ToLiveActivity(liveActivityID string) *ParamBuilder {
+	b.audience.LiveActivityID = liveActivityID
+	return b
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 【可选】设置各平台通用的通知内容，各平台单独设置的 Alert 优先。
+func (b *ParamBuilder) Alert(alert string) *ParamBuilder {
+	b.notification().Alert = alert
+	return b
+}
+
+// 【可选】设置 Android 平台上的通知。
+func (b *ParamBuilder) Android(android *AndroidNotification) *ParamBuilder {
+	b.notification().Android = android
+	return b
+}
+
+// 【可选】设置 iOS 平台上的 APNs 通知。
+func (b *ParamBuilder) IOS(ios *IosNotification) *ParamBuilder {
+	b.notification().IOS = ios
+	return b
+}
+
+// 【可选】设置鸿蒙平台上的通知。
+func (b *ParamBuilder) HMOS(hmos *HmosNotification) *ParamBuilder {
+	b.notification().HMOS = hmos
+	return b
+}
+
+// 【可选】设置快应用平台上的通知。
+func (b *ParamBuilder) QuickApp(quickApp *QuickAppNotification) *ParamBuilder {
+	b.notification().QuickApp = quickApp
+	return b
+}
+
+// 【可选】设置 iOS VoIP 推送内容，任意自定义 key/value 对会透传给 APP。
+func (b *ParamBuilder) VoIP(voip map[string]interface{}) *ParamBuilder {
+	b.notification().VoIP = voip
+	return b
+}
+
+// 【可选】设置自定义消息内容。
+func (b *ParamBuilder) Message(msg *CustomMessage) *ParamBuilder {
+	b.param.CustomMessage = msg
+	return b
+}
+
+// 【可选】设置自定义消息转厂商通知内容（v2 版本），需要与 Message 一起使用，会自动将 Options.Notification3rdVer 设置为 "v2"。
+func (b *ParamBuilder) ThirdNotification(third *ThirdNotificationV2) *ParamBuilder {
+	b.param.ThirdNotification = third
+	b.options().Notification3rdVer = "v2"
+	return b
+}
+
+// 【可选】设置实时活动内容，不能与通知或自定义消息并存。
+func (b *ParamBuilder) LiveActivity(msg *LiveActivityMessage) *ParamBuilder {
+	b.param.LiveActivity = msg
+	return b
+}
+
+// 【可选】设置应用内增强提醒，需要与通知一起使用，不能与自定义消息并存。
+func (b *ParamBuilder) InApp(inApp *InAppMessage) *ParamBuilder {
+	b.param.InApp = inApp
+	return b
+}
+
+// 【可选】设置短信渠道补充送达内容。
+func (b *ParamBuilder) SMS(sms *SmsMessage) *ParamBuilder {
+	b.param.SmsMessage = sms
+	return b
+}
+
+// 【可选】设置回调参数。
+func (b *ParamBuilder) Callback(cb *Callback) *ParamBuilder {
+	b.param.Callback = cb
+	return b
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 【可选】设置推送可选项（会被复制），之后调用的 TTL、ApnsProduction 等方法会在此基础上修改，之前调用的设置会被覆盖。
+func (b *ParamBuilder) Options(opts *Options) *ParamBuilder {
+	if opts == nil {
+		b.param.Options = nil
+		return b
+	}
+	o := *opts
+	if b.param.Options != nil && o.Notification3rdVer == "" {
+		o.Notification3rdVer = b.param.Options.Notification3rdVer
+	}
+	b.param.Options = &o
+	return b
+}
+
+// 【可选】设置离线消息保留时长，精确到秒，为 0 表示不保留离线消息。
+func (b *ParamBuilder) TTL(ttl time.Duration) *ParamBuilder {
+	if ttl < 0 {
+		return b.fail("options.time_to_live", "cannot be negative")
+	}
+	seconds := int64(ttl / time.Second)
+	b.options().TimeToLive = &seconds
+	return b
+}
+
+// 【可选】设置 APNs 是否生产环境，仅在推送平台包含 iOS 时可用。
+func (b *ParamBuilder) ApnsProduction(production bool) *ParamBuilder {
+	b.options().ApnsProduction = &production
+	return b
+}
+
+// 【可选】设置定速推送时长，精确到分钟，最大值为 1400 分钟。
+func (b *ParamBuilder) BigPushDuration(d time.Duration) *ParamBuilder {
+	b.options().BigPushDuration = int(d / time.Minute)
+	return b
+}
+
+// 【可选】设置要覆盖的消息 ID。
+func (b *ParamBuilder) OverrideMsgID(msgID int64) *ParamBuilder {
+	b.options().OverrideMsgID = msgID
+	return b
+}
+
+// 【可选】设置推送计划标识。
+func (b *ParamBuilder) BusinessOperationCode(code string) *ParamBuilder {
+	b.options().BusinessOperationCode = code
+	return b
+}
+
+// 【可选】设置业务消息类别，推送时按照应用的业务消息类别策略自动填充分类相关的字段，详见 SendParam.ApplyClass 说明。
+func (b *ParamBuilder) Class(class MessageClass) *ParamBuilder {
+	b.param.Class = class
+	return b
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 构建并校验推送参数，校验失败时返回 ValidationErrors。
+//   - 返回的 SendParam 不与构建器共享 Options、Notification 和推送目标，Build 之后继续调用构建器的方法不会影响已构建的参数。
+func (b *ParamBuilder) Build() (*SendParam, error) {
+	errs := append(ValidationErrors(nil), b.errs...)
+
+	param := b.param
+	if b.param.Options != nil {
+		opts := *b.param.Options
+		param.Options = &opts
+	}
+	if b.param.Notification != nil {
+		n := *b.param.Notification
+		param.Notification = &n
+	}
+	if len(b.platforms) == 0 {
+		param.Platform = platform.All
+	} else {
+		param.Platform = append([]platform.Platform(nil), b.platforms...)
+	}
+
+	aud := b.audience.Clone()
+	hasTarget := !aud.IsEmpty()
+	if b.broadcast {
+		if hasTarget {
+			errs = append(errs, &ValidationError{Field: "audience", Message: "broadcast cannot be combined with other targets"})
+		}
+		param.Audience = BroadcastAuds
+	} else if hasTarget {
+		param.Audience = aud
+	}
+
+	var paramErrs ValidationErrors
+	if errors.As(param.Validate(), &paramErrs) {
+		errs = append(errs, paramErrs...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return &param, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+)
+
+func TestParamBuilder(t *testing.T) {
+	param, err := push.New().
+		ToAliases("alice", "bob").
+		OnPlatforms(platform.Android, platform.IOS).
+		Android(&push.AndroidNotification{Alert: "Hi, Android!"}).
+		IOS(&push.IosNotification{Alert: "Hi, iOS!"}).
+		ApnsProduction(false).
+		TTL(time.Hour).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	aud, ok := param.Audience.(*push.Audience)
+	if !ok || len(aud.Aliases) != 2 {
+		t.Fatalf("unexpected audience: %#v", param.Audience)
+	}
+	if plats, ok := param.Platform.([]platform.Platform); !ok || len(plats) != 2 {
+		t.Fatalf("unexpected platform: %#v", param.Platform)
+	}
+	if ttl := param.Options.TimeToLive; ttl == nil || *ttl != 3600 {
+		t.Fatalf("unexpected time_to_live: %v", ttl)
+	}
+}
+
+func TestParamBuilder_BuildIsolated(t *testing.T) {
+	b := push.New().ToAliases("alice").Alert("first").TTL(time.Hour)
+	first, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b.ToAliases("bob").Alert("second").TTL(time.Minute)
+	if _, err = b.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if aud := first.Audience.(*push.Audience); len(aud.Aliases) != 1 || aud.Aliases[0] != "alice" {
+		t.Errorf("unexpected aliases after reusing the builder: %v", aud.Aliases)
+	}
+	if alert := first.Notification.Alert; alert != "first" {
+		t.Errorf("unexpected alert after reusing the builder: %q", alert)
+	}
+	if ttl := first.Options.TimeToLive; ttl == nil || *ttl != 3600 {
+		t.Errorf("unexpected time_to_live after reusing the builder: %v", ttl)
+	}
+}
+
+func TestParamBuilder_ThirdNotification(t *testing.T) {
+	param, err := push.New().
+		Broadcast().
+		Message(&push.CustomMessage{Content: "hello"}).
+		ThirdNotification(&push.ThirdNotificationV2{Android: &push.AndroidNotification{Alert: "hello"}}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if param.Platform != platform.All || param.Audience != push.BroadcastAuds || param.Options.Notification3rdVer != "v2" {
+		t.Fatalf("unexpected param: %+v", param)
+	}
+}
+
+func TestParamBuilder_InvalidCombinations(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *push.ParamBuilder
+		field   string
+	}{
+		{"broadcast with aliases", push.New().Broadcast().ToAliases("alice").Alert("hi"), "audience"},
+		{"no target", push.New().Alert("hi"), "audience"},
+		{"live activity with tags", push.New().ToTags("vip").ToLiveActivity("la").Alert("hi"), "audience.live_activity_id"},
+		{"file with aliases", push.New().ToFile("file1").ToAliases("alice").Alert("hi"), "audience.file"},
+		{"apns production without ios", push.New().Broadcast().OnPlatforms(platform.Android).ApnsProduction(true).Alert("hi"), "options.apns_production"},
+		{"negative ttl", push.New().Broadcast().TTL(-time.Second).Alert("hi"), "options.time_to_live"},
+		{"no content", push.New().Broadcast(), "notification"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param, err := tt.builder.Build()
+			if param != nil {
+				t.Fatalf("expected no param, got %+v", param)
+			}
+			if fields := invalidFields(t, err); !fields[tt.field] {
+				t.Errorf("expected an error on %q, got %v", tt.field, fields)
+			}
+		})
+	}
+}
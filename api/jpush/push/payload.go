@@ -0,0 +1,488 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+)
+
+// 推送内容的组成部分，用于按平台和厂商通道统计推送内容的大小。
+type PayloadPart string
+
+const (
+	PayloadTotal    PayloadPart = "total"    // 通知与自定义消息合计
+	PayloadAndroid  PayloadPart = "android"  // Android 平台通知（极光通道）
+	PayloadIOS      PayloadPart = "ios"      // iOS 平台通知（APNs）
+	PayloadHMOS     PayloadPart = "hmos"     // 鸿蒙平台通知
+	PayloadQuickApp PayloadPart = "quickapp" // 快应用平台通知
+
+	// ↓↓↓ Android 厂商通道，仅在 Options.ThirdPartyChannel 中指定了对应厂商时统计 ↓↓↓
+
+	PayloadXiaomi PayloadPart = "xiaomi" // 小米通道
+	PayloadHuawei PayloadPart = "huawei" // 华为通道
+	PayloadHonor  PayloadPart = "honor"  // 荣耀通道
+	PayloadMeizu  PayloadPart = "meizu"  // 魅族通道
+	PayloadOPPO   PayloadPart = "oppo"   // OPPO 通道
+	PayloadVivo   PayloadPart = "vivo"   // vivo 通道
+	PayloadFCM    PayloadPart = "fcm"    // FCM 通道
+	PayloadNIO    PayloadPart = "nio"    // 蔚来通道
+)
+
+// 获取各组成部分默认的推送内容大小限制（单位：字节），按 JSON 序列化后的长度计算，每次调用都返回新的 map。
+//   - 默认值参考极光及各厂商通道的文档，值不大于 0 表示不限制；
+//   - 限制调整时可通过 WithPayloadLimits 覆盖。
+func DefaultPayloadLimits() map[PayloadPart]int {
+	return map[PayloadPart]int{
+		PayloadTotal:    4000,
+		PayloadAndroid:  4000,
+		PayloadIOS:      4000,
+		PayloadHMOS:     4096,
+		PayloadQuickApp: 4000,
+		PayloadXiaomi:   4000,
+		PayloadHuawei:   4096,
+		PayloadHonor:    4096,
+		PayloadMeizu:    1024,
+		PayloadOPPO:     4096,
+		PayloadVivo:     4096,
+		PayloadFCM:      4000,
+		PayloadNIO:      4096,
+	}
+}
+
+// 估算推送内容大小时的配置选项。
+type PayloadOption func(*payloadOptions)
+
+type payloadOptions struct {
+	limits map[PayloadPart]int
+}
+
+// 覆盖给定组成部分的大小限制，未给定的组成部分仍使用 DefaultPayloadLimits 中的默认值，值不大于 0 表示不限制。
+func WithPayloadLimits(limits map[PayloadPart]int) PayloadOption {
+	return func(o *payloadOptions) {
+		for part, limit := range limits {
+			o.limits[part] = limit
+		}
+	}
+}
+
+func newPayloadOptions(opts []PayloadOption) *payloadOptions {
+	o := &payloadOptions{limits: DefaultPayloadLimits()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// 推送内容超出大小限制的错误哨兵，可通过 errors.Is 判断。
+var ErrPayloadTooLarge = errors.New("push payload too large")
+
+// 推送内容某一组成部分的大小。
+type PayloadSize struct {
+	Part  PayloadPart // 组成部分
+	Size  int         // 大小（单位：字节）
+	Limit int         // 大小限制（单位：字节），不大于 0 表示不限制
+	Third bool        // 是否为自定义消息转厂商通知（notification_3rd）的大小
+}
+
+// 是否超出大小限制。
+func (s PayloadSize) Oversized() bool {
+	return s.Limit > 0 && s.Size > s.Limit
+}
+
+func (s PayloadSize) String() string {
+	if s.Third {
+		return fmt.Sprintf("%s (notification_3rd) %d/%d bytes", s.Part, s.Size, s.Limit)
+	}
+	return fmt.Sprintf("%s %d/%d bytes", s.Part, s.Size, s.Limit)
+}
+
+// 推送内容各组成部分的大小报告。
+type PayloadReport []PayloadSize
+
+// 获取超出大小限制的组成部分。
+func (r PayloadReport) Oversized() PayloadReport {
+	var oversized PayloadReport
+	for _, s := range r {
+		if s.Oversized() {
+			oversized = append(oversized, s)
+		}
+	}
+	return oversized
+}
+
+// 如果有组成部分超出大小限制，则返回包装了 ErrPayloadTooLarge 的错误，否则返回 nil。
+func (r PayloadReport) Err() error {
+	oversized := r.Oversized()
+	if len(oversized) == 0 {
+		return nil
+	}
+	parts := make([]string, len(oversized))
+	for i, s := range oversized {
+		parts[i] = s.String()
+	}
+	return fmt.Errorf("%w: %s", ErrPayloadTooLarge, strings.Join(parts, ", "))
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 估算推送内容在各平台和厂商通道上的实际大小，无需发起网络请求，大小限制默认为 DefaultPayloadLimits，可通过 WithPayloadLimits 覆盖。
+//   - 各平台的通知会先以 Notification.Alert 补全未指定的 Alert，再按 JSON 序列化后的长度计算，包括 Extras 等所有字段；
+//   - 只统计 Platform 中指定的平台，厂商通道只统计 Options.ThirdPartyChannel 中指定的厂商，
+//     其大小为通过该厂商下发的 Android 通知与该厂商的通道参数（options.third_party_channel.<厂商>）之和；
+//   - 同时推送自定义消息和 ThirdNotification（notification_3rd）时，还会统计其在各平台和厂商通道上的大小，这些报告项的 Third 为 true；
+//   - 可通过 PayloadReport.Err 判断是否有组成部分超出限制。
+func EstimatePayload(param *SendParam, opts ...PayloadOption) (PayloadReport, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	o := newPayloadOptions(opts)
+	var report PayloadReport
+	add := func(part PayloadPart, third bool, vs ...interface{}) error {
+		var size int
+		for _, v := range vs {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			size += len(data)
+		}
+		report = append(report, PayloadSize{Part: part, Size: size, Limit: o.limits[part], Third: third})
+		return nil
+	}
+	// 统计通过 Android 厂商通道下发的通知内容。
+	addVendors := func(android interface{}, third bool) error {
+		for _, vendor := range payloadVendors(param) {
+			if err := add(vendor.part, third, android, vendor.options); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := add(PayloadTotal, false, struct {
+		Notification  *Notification  `json:"notification,omitempty"`
+		CustomMessage *CustomMessage `json:"message,omitempty"`
+	}{param.Notification, param.CustomMessage}); err != nil {
+		return nil, err
+	}
+
+	targets := payloadTargets(param.Platform)
+	if n := param.Notification; n != nil {
+		if targets(platform.Android) && (n.Android != nil || n.Alert != "") {
+			android := effectiveAndroid(n)
+			if err := add(PayloadAndroid, false, android); err != nil {
+				return nil, err
+			}
+			if err := addVendors(android, false); err != nil {
+				return nil, err
+			}
+		}
+		if targets(platform.IOS) && (n.IOS != nil || n.Alert != "") {
+			if err := add(PayloadIOS, false, effectiveIOS(n)); err != nil {
+				return nil, err
+			}
+		}
+		if targets(platform.HMOS) && (n.HMOS != nil || n.Alert != "") {
+			if err := add(PayloadHMOS, false, effectiveHMOS(n)); err != nil {
+				return nil, err
+			}
+		}
+		if targets(platform.QuickApp) && n.QuickApp != nil {
+			if err := add(PayloadQuickApp, false, effectiveQuickApp(n)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if param.CustomMessage == nil || param.ThirdNotification == nil {
+		return report, nil
+	}
+	third, err := thirdPayloads(param.ThirdNotification)
+	if err != nil {
+		return nil, err
+	}
+	if targets(platform.Android) && third.Android != nil {
+		if err = addVendors(third.Android, true); err != nil {
+			return nil, err
+		}
+	}
+	if targets(platform.IOS) && third.IOS != nil {
+		if err = add(PayloadIOS, true, third.IOS); err != nil {
+			return nil, err
+		}
+	}
+	if targets(platform.HMOS) && third.HMOS != nil {
+		if err = add(PayloadHMOS, true, third.HMOS); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// 将超出大小限制的通知内容截断以满足限制，截断时保证 UTF-8 字符的完整性，返回截断后的大小报告。
+//   - 会直接修改 `param` 中的通知内容：先按 Android.BigText、各平台的 Alert、Notification.Alert 的顺序满足各平台和厂商通道的限制，
+//     再按 Notification.Alert、Android.BigText、各平台的 Alert 的顺序满足合计的限制；
+//   - Alert 至少保留第一个字符，不会被截断为空，以免平台的通知改为显示 Notification.Alert 或没有通知内容；
+//   - 只有在未指定自身 Alert 的平台超出限制时，才会截断这些平台所继承的 Notification.Alert；
+//   - 不会截断 ThirdNotification（notification_3rd），其超出限制时返回的报告的 Err 不为 nil；
+//   - 如果截断所有可截断的字段后仍然超出限制（如 Extras 过大），返回的报告的 Err 不为 nil。
+func TruncatePayload(param *SendParam, opts ...PayloadOption) (PayloadReport, error) {
+	report, err := EstimatePayload(param, opts...)
+	if err != nil || report.Err() == nil || param.Notification == nil {
+		return report, err
+	}
+
+	// 先满足各平台和厂商通道自身的限制，再满足合计的限制，避免单个字段因合计超限而被过度截断。
+	fields := truncatableFields(param.Notification)
+	for _, total := range []bool{false, true} {
+		if total { // 合计超限时优先截断作为各平台默认值的 Notification.Alert
+			last := len(fields) - 1
+			fields = append(fields[last:], fields[:last]...)
+		}
+		for _, field := range fields {
+			for {
+				overflow, value := maxOverflow(report, field.parts, total), field.get()
+				if overflow <= 0 || value == "" {
+					break
+				}
+				maxBytes := len(value) - overflow
+				if field.keep { // Alert 至少保留第一个字符
+					_, size := utf8.DecodeRuneInString(value)
+					maxBytes = max(maxBytes, size)
+				}
+				truncated := truncateUTF8(value, maxBytes)
+				if truncated == value {
+					break
+				}
+				field.set(truncated)
+				if report, err = EstimatePayload(param, opts...); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return report, nil
+}
+
+// 可截断的通知内容字段及其影响的组成部分，`keep` 为 true 时至少保留第一个字符。
+type truncatableField struct {
+	get   func() string
+	set   func(string)
+	parts []PayloadPart
+	keep  bool
+}
+
+func truncatableFields(n *Notification) []truncatableField {
+	vendors := []PayloadPart{PayloadXiaomi, PayloadHuawei, PayloadHonor, PayloadMeizu, PayloadOPPO, PayloadVivo, PayloadFCM, PayloadNIO}
+	android := append([]PayloadPart{PayloadTotal, PayloadAndroid}, vendors...)
+	field := func(s *string, keep bool, parts ...PayloadPart) truncatableField {
+		return truncatableField{func() string { return *s }, func(v string) { *s = v }, parts, keep}
+	}
+
+	var fields []truncatableField
+	inherits := []PayloadPart{PayloadTotal} // 继承 Notification.Alert 的组成部分
+	if n.Android != nil {
+		fields = append(fields, field(&n.Android.BigText, false, android...), field(&n.Android.Alert, true, android...))
+	}
+	if n.Android == nil || n.Android.Alert == "" {
+		inherits = append(inherits, android[1:]...)
+	}
+	if n.IOS != nil {
+		if _, ok := n.IOS.Alert.(string); ok { // alert.IosAlert 结构体形式的 Alert 不做截断
+			fields = append(fields, truncatableField{
+				get:   func() string { s, _ := n.IOS.Alert.(string); return s },
+				set:   func(v string) { n.IOS.Alert = v },
+				parts: []PayloadPart{PayloadTotal, PayloadIOS},
+				keep:  true,
+			})
+		}
+	}
+	if n.IOS == nil || n.IOS.Alert == nil || n.IOS.Alert == "" {
+		inherits = append(inherits, PayloadIOS)
+	}
+	if n.HMOS != nil {
+		fields = append(fields, field(&n.HMOS.Alert, true, PayloadTotal, PayloadHMOS))
+	}
+	if n.HMOS == nil || n.HMOS.Alert == "" {
+		inherits = append(inherits, PayloadHMOS)
+	}
+	if n.QuickApp != nil {
+		fields = append(fields, field(&n.QuickApp.Alert, true, PayloadTotal, PayloadQuickApp))
+		if n.QuickApp.Alert == "" {
+			inherits = append(inherits, PayloadQuickApp)
+		}
+	}
+	return append(fields, field(&n.Alert, true, inherits...))
+}
+
+// 获取给定组成部分中超出限制最多的字节数，`total` 为 true 时只统计合计，否则只统计合计以外的组成部分；不统计 notification_3rd 的大小。
+func maxOverflow(report PayloadReport, parts []PayloadPart, total bool) int {
+	var overflow int
+	for _, s := range report {
+		if !s.Oversized() || s.Third || (s.Part == PayloadTotal) != total {
+			continue
+		}
+		for _, part := range parts {
+			if s.Part == part {
+				overflow = max(overflow, s.Size-s.Limit)
+			}
+		}
+	}
+	return overflow
+}
+
+// 将字符串截断为不超过 maxBytes 字节，且不会截断多字节的 UTF-8 字符。
+func truncateUTF8(s string, maxBytes int) string {
+	if maxBytes <= 0 {
+		return ""
+	}
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 判断推送平台是否包含给定的平台。
+func payloadTargets(plat interface{}) func(platform.Platform) bool {
+	var list []platform.Platform
+	switch v := plat.(type) {
+	case platform.Platform:
+		list = []platform.Platform{v}
+	case string:
+		list = []platform.Platform{platform.Platform(v)}
+	case []platform.Platform:
+		list = v
+	case []string:
+		for _, s := range v {
+			list = append(list, platform.Platform(s))
+		}
+	}
+	return func(p platform.Platform) bool {
+		if len(list) == 0 {
+			return true // 未指定或无法识别时按所有平台统计
+		}
+		for _, l := range list {
+			if l == platform.All || l == p {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// 厂商通道及其通道参数。
+type payloadVendor struct {
+	part    PayloadPart
+	options *ThirdPartyChannelOptions
+}
+
+// 获取 Options.ThirdPartyChannel 中指定的厂商通道，按名称排序。
+func payloadVendors(param *SendParam) []payloadVendor {
+	if param.Options == nil || param.Options.ThirdPartyChannel == nil {
+		return nil
+	}
+	c := param.Options.ThirdPartyChannel
+	var vendors []payloadVendor
+	for part, opts := range map[PayloadPart]*ThirdPartyChannelOptions{
+		PayloadXiaomi: c.Xiaomi, PayloadHuawei: c.Huawei, PayloadHonor: c.Honor, PayloadMeizu: c.Meizu,
+		PayloadOPPO: c.OPPO, PayloadVivo: c.Vivo, PayloadFCM: c.FCM, PayloadNIO: c.NIO,
+	} {
+		if opts != nil {
+			vendors = append(vendors, payloadVendor{part, opts})
+		}
+	}
+	slices.SortFunc(vendors, func(a, b payloadVendor) int { return strings.Compare(string(a.part), string(b.part)) })
+	return vendors
+}
+
+// 自定义消息转厂商通知（notification_3rd）在各平台上的通知内容。
+type thirdPayload struct {
+	Android json.RawMessage `json:"android"`
+	IOS     json.RawMessage `json:"ios"`
+	HMOS    json.RawMessage `json:"hmos"`
+}
+
+// 解析 ThirdNotification 在各平台上的通知内容：v2 版本按平台区分，v1 版本的全部内容均为 Android 通知。
+func thirdPayloads(third interface{}) (thirdPayload, error) {
+	data, err := json.Marshal(third)
+	if err != nil {
+		return thirdPayload{}, err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return thirdPayload{}, fmt.Errorf("invalid `notification_3rd`: %w", err)
+	}
+	_, android := fields["android"]
+	_, ios := fields["ios"]
+	_, hmos := fields["hmos"]
+	if !android && !ios && !hmos {
+		return thirdPayload{Android: data}, nil
+	}
+	return thirdPayload{Android: fields["android"], IOS: fields["ios"], HMOS: fields["hmos"]}, nil
+}
+
+// 以 Notification.Alert 补全未指定 Alert 的各平台通知。
+func effectiveAndroid(n *Notification) AndroidNotification {
+	var a AndroidNotification
+	if n.Android != nil {
+		a = *n.Android
+	}
+	if a.Alert == "" {
+		a.Alert = n.Alert
+	}
+	return a
+}
+
+func effectiveIOS(n *Notification) IosNotification {
+	var i IosNotification
+	if n.IOS != nil {
+		i = *n.IOS
+	}
+	if s, ok := i.Alert.(string); i.Alert == nil || (ok && s == "") {
+		i.Alert = n.Alert
+	}
+	return i
+}
+
+func effectiveHMOS(n *Notification) HmosNotification {
+	var h HmosNotification
+	if n.HMOS != nil {
+		h = *n.HMOS
+	}
+	if h.Alert == "" {
+		h.Alert = n.Alert
+	}
+	return h
+}
+
+func effectiveQuickApp(n *Notification) QuickAppNotification {
+	q := *n.QuickApp
+	if q.Alert == "" {
+		q.Alert = n.Alert
+	}
+	return q
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+)
+
+// 获取报告中给定组成部分的大小。
+func partSize(report push.PayloadReport, part push.PayloadPart) (push.PayloadSize, bool) {
+	for _, s := range report {
+		if s.Part == part {
+			return s, true
+		}
+	}
+	return push.PayloadSize{}, false
+}
+
+func TestEstimatePayload(t *testing.T) {
+	param := &push.SendParam{
+		Platform: []platform.Platform{platform.Android, platform.IOS},
+		Audience: push.BroadcastAuds,
+		Notification: &push.Notification{
+			Alert:   "hello",
+			Android: &push.AndroidNotification{BigText: strings.Repeat("长", 1500)}, // 4500 字节
+		},
+		Options: &push.Options{ThirdPartyChannel: &push.ThirdPartyChannel{Xiaomi: &push.ThirdPartyChannelOptions{}}},
+	}
+	report, err := push.EstimatePayload(param)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, part := range []push.PayloadPart{push.PayloadTotal, push.PayloadAndroid, push.PayloadXiaomi} {
+		if s, ok := partSize(report, part); !ok || !s.Oversized() {
+			t.Errorf("expected %s to be oversized, got %+v", part, s)
+		}
+	}
+	if s, ok := partSize(report, push.PayloadIOS); !ok || s.Oversized() {
+		t.Errorf("expected ios to fit, got %+v", s)
+	}
+	if _, ok := partSize(report, push.PayloadHMOS); ok {
+		t.Error("expected hmos not to be reported when not targeted")
+	}
+	if err = report.Err(); !errors.Is(err, push.ErrPayloadTooLarge) {
+		t.Errorf("expected ErrPayloadTooLarge, got %v", err)
+	}
+}
+
+func TestTruncatePayload(t *testing.T) {
+	param := &push.SendParam{
+		Platform: platform.All,
+		Audience: push.BroadcastAuds,
+		Notification: &push.Notification{
+			Alert: strings.Repeat("通知", 800), // 4800 字节
+			IOS:   &push.IosNotification{Alert: strings.Repeat("é", 500)},
+		},
+	}
+	report, err := push.TruncatePayload(param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = report.Err(); err != nil {
+		t.Fatalf("expected payload to fit after truncation, got %v", err)
+	}
+	if alert := param.Notification.Alert; !utf8.ValidString(alert) || alert == "" {
+		t.Errorf("expected a non-empty valid UTF-8 alert, got %d bytes", len(alert))
+	}
+	if alert := param.Notification.IOS.Alert.(string); !utf8.ValidString(alert) || alert == "" {
+		t.Errorf("expected a non-empty valid UTF-8 iOS alert, got %d bytes", len(alert))
+	}
+}
+
+func TestTruncatePayloadKeepsInheritedAlert(t *testing.T) {
+	param := &push.SendParam{
+		Platform: platform.All,
+		Audience: push.BroadcastAuds,
+		Notification: &push.Notification{
+			Alert: "hello",
+			Android: &push.AndroidNotification{
+				Alert:  "安卓通知",
+				Extras: map[string]interface{}{"payload": strings.Repeat("x", 1500)}, // 超出魅族通道的 1024 字节限制
+			},
+		},
+		Options: &push.Options{ThirdPartyChannel: &push.ThirdPartyChannel{Meizu: &push.ThirdPartyChannelOptions{}}},
+	}
+	report, err := push.TruncatePayload(param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = report.Err(); !errors.Is(err, push.ErrPayloadTooLarge) {
+		t.Errorf("expected the oversized extras to be reported, got %v", err)
+	}
+	if alert := param.Notification.Android.Alert; alert != "安" {
+		t.Errorf("expected the android alert to keep its first character, got %q", alert)
+	}
+	if alert := param.Notification.Alert; alert != "hello" {
+		t.Errorf("expected the alert inherited by ios to stay intact, got %q", alert)
+	}
+	if s, ok := partSize(report, push.PayloadIOS); !ok || s.Oversized() {
+		t.Errorf("expected ios to fit, got %+v", s)
+	}
+}
+
+func TestEstimatePayloadVendorsAndThird(t *testing.T) {
+	param := &push.SendParam{
+		Platform: platform.Android,
+		Audience: push.BroadcastAuds,
+		Notification: &push.Notification{
+			Alert: "hello",
+		},
+		CustomMessage:     &push.CustomMessage{Content: "msg"},
+		ThirdNotification: &push.ThirdNotificationV2{Android: &push.AndroidNotification{Alert: strings.Repeat("x", 1100)}},
+		Options: &push.Options{ThirdPartyChannel: &push.ThirdPartyChannel{
+			Xiaomi: &push.ThirdPartyChannelOptions{},
+			Meizu:  &push.ThirdPartyChannelOptions{Distribution: "ospush", ChannelID: strings.Repeat("c", 100)},
+		}},
+	}
+	report, err := push.EstimatePayload(param)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sizes := make(map[push.PayloadPart][]push.PayloadSize)
+	for _, s := range report {
+		sizes[s.Part] = append(sizes[s.Part], s)
+	}
+	xiaomi, meizu := sizes[push.PayloadXiaomi], sizes[push.PayloadMeizu]
+	if len(xiaomi) != 2 || len(meizu) != 2 {
+		t.Fatalf("expected a notification and a notification_3rd entry per vendor, got %v", report)
+	}
+	// 各厂商的大小包括自身的通道参数。
+	if meizu[0].Size <= xiaomi[0].Size+100 {
+		t.Errorf("expected meizu to include its channel options, got %v and %v", meizu[0], xiaomi[0])
+	}
+	if meizu[0].Third || !meizu[1].Third || meizu[0].Oversized() || !meizu[1].Oversized() {
+		t.Errorf("expected only the meizu notification_3rd to be oversized, got %v", meizu)
+	}
+	if err = report.Err(); err == nil || !strings.Contains(err.Error(), "meizu (notification_3rd)") {
+		t.Errorf("expected the notification_3rd to be reported, got %v", err)
+	}
+
+	// 不截断 notification_3rd，也不会因为它而截断通知内容。
+	report, err = push.TruncatePayload(param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if param.Notification.Alert != "hello" || !errors.Is(report.Err(), push.ErrPayloadTooLarge) {
+		t.Errorf("unexpected truncation: alert %q, err %v", param.Notification.Alert, report.Err())
+	}
+
+	// 覆盖大小限制。
+	report, err = push.EstimatePayload(param, push.WithPayloadLimits(map[push.PayloadPart]int{push.PayloadMeizu: 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = report.Err(); err != nil {
+		t.Errorf("expected no limit for meizu, got %v", err)
+	}
+	if push.DefaultPayloadLimits()[push.PayloadMeizu] != 1024 {
+		t.Error("WithPayloadLimits should not change the default limits")
+	}
+}
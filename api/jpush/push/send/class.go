@@ -0,0 +1,115 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package send
+
+import (
+	"fmt"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/hmos"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+// 按照业务消息类别策略 `policy` 应用推送参数的业务消息类别 Class，返回应用后的推送参数副本，原推送参数保持不变。
+//   - Class 为空时直接返回原推送参数；`policy` 为 nil 时使用 options.DefaultClassPolicy；策略中不存在该类别时返回错误；
+//   - 只填充推送参数中未设置的字段，开发者显式设置的值优先，包括：Options.Classification，
+//     Options.ThirdPartyChannel 中各厂商的 ChannelID、Classification、Importance、Category、NotifyLevel，
+//     以及 Notification 中 Android 的 Category、ChannelID 和 HMOS 的 Category（仅在对应平台的通知已设置时，HMOS 应用内通话消息除外）。
+func (p *Param) ApplyClass(policy options.ClassPolicy) (*Param, error) {
+	if p == nil || p.Class == "" {
+		return p, nil
+	}
+	if policy == nil {
+		policy = options.DefaultClassPolicy()
+	}
+	profile, ok := policy[p.Class]
+	if !ok {
+		return nil, fmt.Errorf("unknown message class %q", p.Class)
+	}
+
+	c := *p
+	var opts options.Options
+	if p.Options != nil {
+		opts = *p.Options
+	}
+	if opts.Classification == nil {
+		opts.Classification = profile.Classification.Ptr()
+	}
+	var channel options.ThirdPartyChannel
+	if opts.ThirdPartyChannel != nil {
+		channel = *opts.ThirdPartyChannel
+	}
+	pc := profile.ThirdPartyChannel
+	channel.Xiaomi = mergeChannelOptions(channel.Xiaomi, pc.Xiaomi)
+	channel.Huawei = mergeChannelOptions(channel.Huawei, pc.Huawei)
+	channel.Honor = mergeChannelOptions(channel.Honor, pc.Honor)
+	channel.Meizu = mergeChannelOptions(channel.Meizu, pc.Meizu)
+	channel.OPPO = mergeChannelOptions(channel.OPPO, pc.OPPO)
+	channel.Vivo = mergeChannelOptions(channel.Vivo, pc.Vivo)
+	channel.FCM = mergeChannelOptions(channel.FCM, pc.FCM)
+	channel.NIO = mergeChannelOptions(channel.NIO, pc.NIO)
+	if channel != (options.ThirdPartyChannel{}) {
+		opts.ThirdPartyChannel = &channel
+	}
+	c.Options = &opts
+
+	if p.Notification != nil {
+		n := *p.Notification
+		if n.Android != nil {
+			android := *n.Android
+			if android.Category == "" {
+				android.Category = profile.AndroidCategory
+			}
+			if android.ChannelID == "" {
+				android.ChannelID = profile.AndroidChannelID
+			}
+			n.Android = &android
+		}
+		if n.HMOS != nil && n.HMOS.Category == "" && n.HMOS.PushType != hmos.PushTypeVoIPCall {
+			hmos := *n.HMOS
+			hmos.Category = profile.HMOSCategory
+			n.HMOS = &hmos
+		}
+		c.Notification = &n
+	}
+	return &c, nil
+}
+
+// 将策略中的厂商通道参数 `defaults` 合并到推送参数的厂商通道参数 `dst` 中，只填充未设置的分类相关字段，返回新的副本。
+func mergeChannelOptions(dst, defaults *options.ThirdPartyChannelOptions) *options.ThirdPartyChannelOptions {
+	if defaults == nil {
+		return dst
+	}
+	var merged options.ThirdPartyChannelOptions
+	if dst != nil {
+		merged = *dst
+	}
+	if merged.ChannelID == "" {
+		merged.ChannelID = defaults.ChannelID
+	}
+	if merged.Classification == nil && defaults.Classification != nil {
+		v := *defaults.Classification
+		merged.Classification = &v
+	}
+	if merged.Importance == "" {
+		merged.Importance = defaults.Importance
+	}
+	if merged.Category == "" {
+		merged.Category = defaults.Category
+	}
+	if merged.NotifyLevel == 0 {
+		merged.NotifyLevel = defaults.NotifyLevel
+	}
+	return &merged
+}
@@ -81,4 +81,13 @@ type Param struct {
 	//  - 详见 [docs.jiguang.cn] 文档说明。
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_push#callback%EF%BC%9A%E5%9B%9E%E8%B0%83%E5%8F%82%E6%95%B0
 	Callback *callback.Callback `json:"callback,omitempty"`
+	// 【可选】业务消息类别，不参与序列化。
+	//  - 指定后，推送接口会按照应用的业务消息类别策略（默认为 options.DefaultClassPolicy）自动填充 Options.Classification、
+	//  Options.ThirdPartyChannel 中各厂商的分类参数，以及 Android、HarmonyOS 通知的 Category 等未设置的字段；
+	//  - 自动应用的范围：push.APIv3 中接收 *SendParam 的推送接口（Send、SendWithSM2、SendByFile、ValidateSend，
+	//  以及传入 *SendParam 的 CustomSend、CustomSendByFile、ValidateCustomSend），和基于它们的 push.Outbox、push.Aggregator、push.Dispatcher；
+	//  - 由于不参与序列化，以下情况不会应用，需要先调用 Param.ApplyClass 获取应用后的推送参数：以 map、json.RawMessage 等
+	//  其他形式传入 Custom* 接口的推送参数，分组推送（gpush）、定时任务（schedule.Push）和 UMS 的 APP 消息；
+	//  - 详见 Param.ApplyClass 说明。
+	Class options.MessageClass `json:"-"`
 }
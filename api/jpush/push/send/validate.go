@@ -0,0 +1,205 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package send
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/audience"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification"
+)
+
+type (
+	ValidationError  = api.ValidationError  // 推送参数中单个字段的校验错误。
+	ValidationErrors = api.ValidationErrors // 推送参数的所有校验错误，按字段的检查顺序排列。
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 在本地按照 JPush 文档中的约束校验推送参数，无需发起网络请求，也不消耗推送配额。
+//
+// 校验通过时返回 nil，否则返回包含所有字段错误的 ValidationErrors，主要检查：
+//   - Platform 必须为 platform.All 或有效的平台列表；
+//   - Audience 必须为 push.BroadcastAuds 或有效的推送设备对象，各类目标的数量和长度不超过限制，且 LiveActivityID、File 不能与其他目标组合使用；
+//   - Notification、CustomMessage 和 LiveActivity 必须有其一，且 LiveActivity 不能与前两者并存，CustomMessage 的消息内容不能为空；
+//   - Notification.HMOS 通过 HMOS.Validate 的校验（包括不同推送类型的必填和禁用字段）；
+//   - ThirdNotification 需要与 CustomMessage 一起使用，不能与 Notification 并存，且 ThirdNotificationV2 要求 Options.Notification3rdVer 为 "v2"；
+//   - Options 通过 Options.Validate 的校验（包括各厂商通道参数的取值），且 ApnsProduction 仅在推送 iOS 平台时可用。
+//
+// 注意：校验只覆盖文档中明确的约束，通过校验并不保证推送一定成功，如需完整的服务端校验，请使用 ValidateSend 接口。
+func (p *Param) Validate() error {
+	if p == nil {
+		return ValidationErrors{{Field: "param", Message: "cannot be nil"}}
+	}
+	var errs ValidationErrors
+	plats := validatePlatform(&errs, "platform", p.Platform)
+	validateAudience(&errs, "audience", p.Audience)
+	validateContent(&errs, p, plats)
+	return errs.Err()
+}
+
+// 校验推送平台，返回推送的平台集合，nil 表示所有平台。
+func validatePlatform(errs *ValidationErrors, field string, value interface{}) map[platform.Platform]bool {
+	var list []platform.Platform
+	switch v := value.(type) {
+	case nil:
+		errs.Add(field, "is required")
+		return nil
+	case platform.Platform:
+		if v == platform.All {
+			return nil
+		}
+		list = []platform.Platform{v}
+	case string:
+		if v == string(platform.All) {
+			return nil
+		}
+		list = []platform.Platform{platform.Platform(v)}
+	case []platform.Platform:
+		list = v
+	case []string:
+		for _, s := range v {
+			list = append(list, platform.Platform(s))
+		}
+	default:
+		errs.Add(field, "must be platform.All or a list of platforms, got %T", value)
+		return nil
+	}
+
+	if len(list) == 0 {
+		errs.Add(field, "cannot be an empty list")
+		return nil
+	}
+	plats := make(map[platform.Platform]bool, len(list))
+	for i, p := range list {
+		switch p {
+		case platform.Android, platform.IOS, platform.QuickApp, platform.HMOS:
+		case platform.All:
+			errs.Add(fmt.Sprintf("%s[%d]", field, i), "`all` cannot be combined with other platforms")
+			return nil
+		default:
+			errs.Add(fmt.Sprintf("%s[%d]", field, i), "unsupported platform %q", p)
+			continue
+		}
+		if plats[p] {
+			errs.Add(fmt.Sprintf("%s[%d]", field, i), "duplicate platform %q", p)
+		}
+		plats[p] = true
+	}
+	return plats
+}
+
+// 校验推送目标。
+func validateAudience(errs *ValidationErrors, field string, value interface{}) {
+	var aud *audience.Audience
+	switch v := value.(type) {
+	case nil:
+		errs.Add(field, "is required")
+		return
+	case string:
+		if v != audience.All {
+			errs.Add(field, "must be %q or a push.Audience, got %q", audience.All, v)
+		}
+		return
+	case audience.Audience:
+		aud = &v
+	case *audience.Audience:
+		if v == nil {
+			errs.Add(field, "is required")
+			return
+		}
+		aud = v
+	default:
+		return // 如 map 等自行构建的推送目标，交由服务端校验
+	}
+
+	var audErrs ValidationErrors
+	if errors.As(aud.Validate(), &audErrs) {
+		*errs = append(*errs, audErrs.WithPrefix(field)...)
+	}
+}
+
+// 校验推送内容和推送可选项，`plats` 为推送的平台集合，nil 表示所有平台。
+func validateContent(errs *ValidationErrors, p *Param, plats map[platform.Platform]bool) {
+	if p.Notification == nil && p.CustomMessage == nil && p.LiveActivity == nil {
+		errs.Add("notification", "either notification or message is required")
+	}
+	if p.CustomMessage != nil && p.CustomMessage.Content == "" {
+		errs.Add("message.msg_content", "is required")
+	}
+	if p.LiveActivity != nil && (p.Notification != nil || p.CustomMessage != nil) {
+		errs.Add("live_activity", "cannot be combined with notification or message")
+	}
+	if p.Notification != nil && p.Notification.HMOS != nil {
+		var hmosErrs ValidationErrors
+		if errors.As(p.Notification.HMOS.Validate(), &hmosErrs) {
+			*errs = append(*errs, hmosErrs.WithPrefix("notification.hmos")...)
+		}
+	}
+	if p.InApp != nil {
+		if p.Notification == nil {
+			errs.Add("inapp_message", "requires notification")
+		}
+		if p.CustomMessage != nil {
+			errs.Add("inapp_message", "cannot be combined with message")
+		}
+	}
+
+	var ver string
+	if p.Options != nil {
+		ver = p.Options.Notification3rdVer
+	}
+	if p.ThirdNotification != nil {
+		if p.CustomMessage == nil {
+			errs.Add("notification_3rd", "requires message")
+		}
+		if p.Notification != nil {
+			errs.Add("notification_3rd", "cannot be combined with notification")
+		}
+		switch p.ThirdNotification.(type) {
+		case *notification.ThirdV2, notification.ThirdV2:
+			if ver != "v2" {
+				errs.Add("options.notification_3rd_ver", "must be v2 when notification_3rd is a ThirdNotificationV2")
+			}
+		case *notification.Third, notification.Third: // nolint:staticcheck
+			if ver == "v2" {
+				errs.Add("options.notification_3rd_ver", "must be v1 or empty when notification_3rd is a ThirdNotification")
+			}
+		}
+	}
+
+	o := p.Options
+	if o == nil {
+		return
+	}
+	var optErrs ValidationErrors
+	if errors.As(o.Validate(), &optErrs) {
+		*errs = append(*errs, optErrs.WithPrefix("options")...)
+	}
+	if o.ApnsProduction != nil && plats != nil && !plats[platform.IOS] {
+		errs.Add("options.apns_production", "applies only to iOS, but platform does not include ios")
+	}
+	if o.ActivePush != nil && *o.ActivePush {
+		if p.CustomMessage != nil {
+			errs.Add("options.active_push", "cannot be used with message")
+		}
+		if o.BigPushDuration > 0 {
+			errs.Add("options.active_push", "cannot be used with big_push_duration")
+		}
+	}
+}
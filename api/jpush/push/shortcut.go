@@ -35,6 +35,10 @@ type (
 	//
 	// 创建模板时，开发者设置的变量参数。
 	TemplateParam = send.TemplateParam
+	// # 推送参数的字段校验错误
+	ValidationError = send.ValidationError
+	// # 推送参数的所有校验错误
+	ValidationErrors = send.ValidationErrors
 
 	// # 获取推送唯一标识 (CID) 结果
 	CidGetResult = cid.GetResult
@@ -59,6 +63,12 @@ type (
 	//
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_push#options%EF%BC%9A%E5%8F%AF%E9%80%89%E5%8F%82%E6%95%B0
 	Options = options.Options
+	// # 业务消息类别
+	MessageClass = options.MessageClass
+	// # 业务消息类别的推送配置
+	ClassProfile = options.ClassProfile
+	// # 业务消息类别策略
+	ClassPolicy = options.ClassPolicy
 	// # 推送请求下发通道
 	ThirdPartyChannel = options.ThirdPartyChannel
 	// # 推送请求下发通道的策略和属性参数
@@ -164,6 +174,11 @@ type (
 const (
 	BroadcastAuds = audience.All // 广播推送，表示推送给所有设备 (all)。
 
+	ClassTransactional = options.ClassTransactional // 业务消息类别：交易类消息
+	ClassSocial        = options.ClassSocial        // 业务消息类别：社交通讯类消息
+	ClassReminder      = options.ClassReminder      // 业务消息类别：提醒类消息
+	ClassMarketing     = options.ClassMarketing     // 业务消息类别：营销类消息
+
 	HmosPushTypeAlert      = hmos.PushTypeAlert      // 华为场景化消息类型：通知消息 (0)
 	HmosPushTypeSubscribe  = hmos.PushTypeSubscribe  // 华为场景化消息类型：授权订阅消息 (0)
 	HmosPushTypeFormUpdate = hmos.PushTypeFormUpdate // 华为场景化消息类型：卡片刷新消息 (1)
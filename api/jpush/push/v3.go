@@ -16,7 +16,9 @@ package push
 
 import (
 	"context"
+	"iter"
 
+	"github.com/cavlabs/jiguang-sdk-go/api"
 	"github.com/cavlabs/jiguang-sdk-go/api/jpush/file"
 	"github.com/cavlabs/jiguang-sdk-go/api/jpush/image"
 	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
@@ -79,6 +81,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_push_single
 	BatchSendByRegistrationID(ctx context.Context, pushList map[string]BatchPushParam) (*BatchSendResult, error)
 
+	// # 批量单推（Registration ID 方式，自动分块）
+	//  - 功能说明：批量单推任意数量的推送，`pushList` 超过 1000 个时按 CID 排序后自动拆分为多次 BatchSendByRegistrationID 调用，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行并合并结果。
+	//  - 部分分块失败时，返回成功分块的合并结果（key 为 CID 值），以及 api.ChunkErrors 错误，其中每个失败分块的错误为请求错误或 *api.APIError。
+	BatchSendByRegistrationIDAll(ctx context.Context, pushList map[string]BatchPushParam, opts ...api.ChunkOption) (map[string]BatchPushResult, error)
+
 	// # 批量单推（Alias 方式）
 	//  - 功能说明：如果您在给每个用户的推送内容都不同的情况下，可以使用此接口。使用此接口前，您需要配合使用 GetCidForPush 接口提前获取到 CID 池。
 	//	- 调用地址：POST `/v3/push/batch/alias/single`，`pushList` 的 key 为 CID 值，最多支持填写 1000 个。
@@ -86,6 +93,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_push_single
 	BatchSendByAlias(ctx context.Context, pushList map[string]BatchPushParam) (*BatchSendResult, error)
 
+	// # 批量单推（Alias 方式，自动分块）
+	//  - 功能说明：批量单推任意数量的推送，`pushList` 超过 1000 个时按 CID 排序后自动拆分为多次 BatchSendByAlias 调用，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行并合并结果。
+	//  - 部分分块失败时，返回成功分块的合并结果（key 为 CID 值），以及 api.ChunkErrors 错误，其中每个失败分块的错误为请求错误或 *api.APIError。
+	BatchSendByAliasAll(ctx context.Context, pushList map[string]BatchPushParam, opts ...api.ChunkOption) (map[string]BatchPushResult, error)
+
 	// # 普通模板推送（VIP）
 	//
 	// 指定模板 ID，模板参数（如有设置），进行立即推送。
@@ -154,6 +166,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_push_plan#%E6%9F%A5%E8%AF%A2%E6%8E%A8%E9%80%81%E8%AE%A1%E5%88%92%E5%88%97%E8%A1%A8
 	ListPlans(ctx context.Context, page, pageSize int, info string, sendSource int) (*PlansListResult, error)
 
+	// # 遍历所有推送计划（VIP）
+	//  - 功能说明：按页惰性调用 ListPlans，逐个产生符合 `filter` 条件（为 nil 时不过滤）的推送计划，可直接用于 range 循环；`opts` 为可选的分页选项，如 api.WithPrefetch() 启用下一页的预取。
+	//  - 拉取失败（包括业务错误，以 *api.APIError 返回）或 ctx 被取消时，迭代器产生一次错误后结束。
+	AllPlans(ctx context.Context, filter *PlanFilter, opts ...api.PageOption) iter.Seq2[PlanDetail, error]
+
 	// ********************* ↓↓↓ 如果遇到此 API 没有及时补充字段的情况，可以自行构建 JSON，调用下面的接口 ↓↓↓ *********************
 
 	// # 自定义推送
@@ -0,0 +1,65 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// 推送计划列表的查询条件，提供给 AllPlans 使用。
+type PlanFilter struct {
+	Info       string // 【可选】推送计划或者推送标识，只要其中之一匹配到即可（模糊查询）
+	SendSource *int   // 【可选】创建来源，0 表示 API，1 表示 web（控制台创建），为 nil 时表示不区分
+	PageSize   int    // 【可选】每页记录条数，不大于 0 时默认为 50
+}
+
+// # 遍历所有推送计划（VIP）
+//   - 功能说明：按页惰性调用 ListPlans，逐个产生符合 `filter` 条件的推送计划，可直接用于 range 循环；
+//     `filter` 为 nil 时表示不过滤；`opts` 为可选的分页选项，如 api.WithPrefetch() 启用下一页的预取。
+//   - 拉取失败（包括业务错误，以 *api.APIError 返回）或 ctx 被取消时，迭代器产生一次错误后结束。
+func (p *apiv3) AllPlans(ctx context.Context, filter *PlanFilter, opts ...api.PageOption) iter.Seq2[PlanDetail, error] {
+	var f PlanFilter
+	if filter != nil {
+		f = *filter
+	}
+	if f.PageSize <= 0 {
+		f.PageSize = 50
+	}
+	sendSource := -1 // 非 0 和 1 值表示不区分
+	if f.SendSource != nil {
+		sendSource = *f.SendSource
+	}
+
+	return api.Paginate(ctx, func(ctx context.Context, page int) ([]PlanDetail, bool, error) {
+		if p == nil {
+			return nil, false, api.ErrNilJPushPushAPIv3
+		}
+		result, err := p.ListPlans(ctx, page, f.PageSize, f.Info, sendSource)
+		if err != nil {
+			return nil, false, err
+		}
+		if !result.IsSuccess() {
+			return nil, false, api.NewAPIError(http.MethodGet+" /v3/push_plan/list", result.Response, result.CodeError)
+		}
+		if result.Data == nil {
+			return nil, false, nil
+		}
+		return result.Data.Detail, len(result.Data.Detail) > 0 && page*f.PageSize < result.Data.Total, nil
+	}, opts...)
+}
@@ -61,13 +61,20 @@ func (p *apiv3) batchSend(ctx context.Context, byType string, pushList map[strin
 	if l > 1000 {
 		return nil, errors.New("`pushList` cannot be more than 1000")
 	}
+	if p.validation {
+		if err := validatePushList(pushList); err != nil {
+			return nil, err
+		}
+	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push/batch/" + byType + "/single",
-		Auth:   p.auth,
-		Body:   &batchSendParam{PushList: pushList},
+		Method:     http.MethodPost,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push/batch/" + byType + "/single",
+		Authorizer: p.auth,
+		Body:       &batchSendParam{PushList: pushList},
+
+		Idempotent: true, // `pushList` 的 key 为 CID 值
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -0,0 +1,77 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// # 批量单推（Registration ID 方式，自动分块）
+//   - 功能说明：批量单推任意数量的推送，`pushList` 会按 CID 排序后拆分为每块最多 1000 个，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数分别调用 BatchSendByRegistrationID 并合并结果。
+//   - 部分分块失败时，返回成功分块的合并结果（key 为 CID 值），以及 api.ChunkErrors 错误（其中的 Start/End 为分块在排序后的 CID 列表中的位置）。
+func (p *apiv3) BatchSendByRegistrationIDAll(ctx context.Context, pushList map[string]BatchPushParam, opts ...api.ChunkOption) (map[string]BatchPushResult, error) {
+	return p.batchSendAll(ctx, "regid", pushList, opts...)
+}
+
+// # 批量单推（Alias 方式，自动分块）
+//   - 功能说明：批量单推任意数量的推送，`pushList` 会按 CID 排序后拆分为每块最多 1000 个，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数分别调用 BatchSendByAlias 并合并结果。
+//   - 部分分块失败时，返回成功分块的合并结果（key 为 CID 值），以及 api.ChunkErrors 错误（其中的 Start/End 为分块在排序后的 CID 列表中的位置）。
+func (p *apiv3) BatchSendByAliasAll(ctx context.Context, pushList map[string]BatchPushParam, opts ...api.ChunkOption) (map[string]BatchPushResult, error) {
+	return p.batchSendAll(ctx, "alias", pushList, opts...)
+}
+
+func (p *apiv3) batchSendAll(ctx context.Context, byType string, pushList map[string]BatchPushParam, opts ...api.ChunkOption) (map[string]BatchPushResult, error) {
+	if p == nil {
+		return nil, api.ErrNilJPushPushAPIv3
+	}
+	if len(pushList) == 0 {
+		return nil, errors.New("`pushList` cannot be empty")
+	}
+
+	cids := make([]string, 0, len(pushList))
+	for cid := range pushList {
+		cids = append(cids, cid)
+	}
+	slices.Sort(cids)
+
+	var mu sync.Mutex
+	merged := make(map[string]BatchPushResult, len(pushList))
+	err := api.ForEachChunk(ctx, cids, 1000, func(ctx context.Context, _ int, chunk []string) error {
+		part := make(map[string]BatchPushParam, len(chunk))
+		for _, cid := range chunk {
+			part[cid] = pushList[cid]
+		}
+		result, err := p.batchSend(ctx, byType, part)
+		if err != nil {
+			return err
+		}
+		if !result.IsSuccess() {
+			return api.NewAPIError(http.MethodPost+" /v3/push/batch/"+byType+"/single", result.Response, result.Error)
+		}
+		mu.Lock()
+		for cid, r := range result.SendResult {
+			merged[cid] = r
+		}
+		mu.Unlock()
+		return nil
+	}, opts...)
+	return merged, err
+}
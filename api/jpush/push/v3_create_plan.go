@@ -40,11 +40,11 @@ func (p *apiv3) CreatePlan(ctx context.Context, param *PlanCreateParam) (*PlanCr
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push_plan/create",
-		Auth:   p.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push_plan/create",
+		Authorizer: p.auth,
+		Body:       param,
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -40,10 +40,10 @@ func (p *apiv3) GetCidForPush(ctx context.Context, count int) (*CidGetResult, er
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push/cid?type=push&count=" + strconv.Itoa(count),
-		Auth:   p.auth,
+		Method:     http.MethodGet,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push/cid?type=push&count=" + strconv.Itoa(count),
+		Authorizer: p.auth,
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
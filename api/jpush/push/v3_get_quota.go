@@ -40,10 +40,10 @@ func (p *apiv3) GetQuota(ctx context.Context) (*QuotaGetResult, error) {
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push/quota",
-		Auth:   p.auth,
+		Method:     http.MethodGet,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push/quota",
+		Authorizer: p.auth,
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
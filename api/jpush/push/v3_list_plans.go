@@ -41,14 +41,14 @@ func (p *apiv3) ListPlans(ctx context.Context, page, pageSize int, info string,
 		page = 1
 	}
 	if pageSize < 1 {
-		page = 50
+		pageSize = 50
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  p.proto,
-		URL:    fmt.Sprintf("%s/v3/push_plan/list?page=%d&page_size=%d&info=%s&send_source=%d", p.host, page, pageSize, info, sendSource),
-		Auth:   p.auth,
+		Method:     http.MethodGet,
+		Proto:      p.proto,
+		URL:        fmt.Sprintf("%s/v3/push_plan/list?page=%d&page_size=%d&info=%s&send_source=%d", p.host, page, pageSize, info, sendSource),
+		Authorizer: p.auth,
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/cid"
 	"github.com/cavlabs/jiguang-sdk-go/jiguang"
 )
 
@@ -45,13 +46,24 @@ func (p *apiv3) CustomSend(ctx context.Context, param interface{}) (*SendResult,
 	if param == nil {
 		return nil, errors.New("`param` cannot be nil")
 	}
+	if sp, ok := param.(*SendParam); ok {
+		prepared, err := p.prepare(sp)
+		if err != nil {
+			return nil, err
+		}
+		param = prepared
+	} else if err := p.validate(param); err != nil {
+		return nil, err
+	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push",
-		Auth:   p.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push",
+		Authorizer: p.auth,
+		Body:       param,
+
+		Idempotent: cid.Carried(param),
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -80,6 +92,10 @@ func (p *apiv3) SendWithSM2(ctx context.Context, param *SendParam) (*SendResult,
 	if param == nil {
 		return nil, errors.New("`param` cannot be nil")
 	}
+	param, err := p.prepare(param)
+	if err != nil {
+		return nil, err
+	}
 
 	original, err := json.Marshal(param)
 	if err != nil {
@@ -92,12 +108,14 @@ func (p *apiv3) SendWithSM2(ctx context.Context, param *SendParam) (*SendResult,
 	sm2PushParam := &sm2Push{Audience: param.Audience, Payload: payload}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push",
-		Auth:   p.auth,
-		Header: http.Header{"X-Encrypt-Type": {"SM2"}},
-		Body:   sm2PushParam,
+		Method:     http.MethodPost,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push",
+		Authorizer: p.auth,
+		Header:     http.Header{"X-Encrypt-Type": {"SM2"}},
+		Body:       sm2PushParam,
+
+		Idempotent: cid.Carried(param),
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -112,6 +130,37 @@ func (p *apiv3) SendWithSM2(ctx context.Context, param *SendParam) (*SendResult,
 	return result, nil
 }
 
+// 发送前准备推送参数：应用业务消息类别，并在启用了发送前的本地校验时进行校验，返回最终发送的推送参数。
+func (p *apiv3) prepare(param *SendParam) (*SendParam, error) {
+	param, err := param.ApplyClass(p.classPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.validate(param); err != nil {
+		return nil, err
+	}
+	return param, nil
+}
+
+// 推送参数为 *SendParam 时应用其业务消息类别，其他形式的推送参数原样返回。
+func (p *apiv3) applyClass(param interface{}) (interface{}, error) {
+	if sp, ok := param.(*SendParam); ok {
+		return sp.ApplyClass(p.classPolicy)
+	}
+	return param, nil
+}
+
+// 启用了发送前的本地校验时，校验实现了 Validate() error 的推送参数。
+func (p *apiv3) validate(param interface{}) error {
+	if !p.validation {
+		return nil
+	}
+	if v, ok := param.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
 // SM2 加密推送参数
 type sm2Push struct {
 	Audience interface{} `json:"audience"` // 推送目标，同 SendParam.Audience
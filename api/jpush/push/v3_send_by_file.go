@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/cid"
 )
 
 // # 文件推送（文件立即推送）
@@ -49,13 +50,19 @@ func (p *apiv3) CustomSendByFile(ctx context.Context, param interface{}) (*SendR
 	if param == nil {
 		return nil, errors.New("`param` cannot be nil")
 	}
+	param, err := p.applyClass(param)
+	if err != nil {
+		return nil, err
+	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push/file",
-		Auth:   p.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push/file",
+		Authorizer: p.auth,
+		Body:       param,
+
+		Idempotent: cid.Carried(param),
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -54,11 +54,11 @@ func (p *apiv3) TemplateSend(ctx context.Context, id string, params []TemplatePa
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push/template",
-		Auth:   p.auth,
-		Body:   &templateSendParam{ID: id, Params: params},
+		Method:     http.MethodPost,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push/template",
+		Authorizer: p.auth,
+		Body:       &templateSendParam{ID: id, Params: params},
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
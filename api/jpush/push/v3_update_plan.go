@@ -39,11 +39,11 @@ func (p *apiv3) UpdatePlan(ctx context.Context, param *PlanUpdateParam) (*PlanUp
 	}
 
 	req := &api.Request{
-		Method: http.MethodPut,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push_plan/update",
-		Auth:   p.auth,
-		Body:   param,
+		Method:     http.MethodPut,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push_plan/update",
+		Authorizer: p.auth,
+		Body:       param,
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -0,0 +1,69 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/audience"
+)
+
+// 在本地按照 JPush 文档中的约束校验批量推送参数，无需发起网络请求，也不消耗推送配额。
+//   - 除 Target 不能为空外，其余校验规则与 SendParam.Validate 相同（不包括推送目标 Audience 的校验）；
+//   - 校验通过时返回 nil，否则返回包含所有字段错误的 ValidationErrors。
+func (p *BatchPushParam) Validate() error {
+	if p == nil {
+		return ValidationErrors{{Field: "param", Message: "cannot be nil"}}
+	}
+	var errs ValidationErrors
+	if p.Target == "" {
+		errs.Add("target", "is required")
+	}
+	err := (&SendParam{
+		Platform:          p.Platform,
+		Audience:          audience.All, // 批量单推的推送目标为 Target，此处仅用于通过推送目标的校验
+		Options:           p.Options,
+		Notification:      p.Notification,
+		CustomMessage:     p.CustomMessage,
+		ThirdNotification: p.ThirdNotification,
+		SmsMessage:        p.SmsMessage,
+		Callback:          p.Callback,
+	}).Validate()
+	var contentErrs ValidationErrors
+	if errors.As(err, &contentErrs) {
+		errs = append(errs, contentErrs...)
+	}
+	return errs.Err()
+}
+
+// 校验批量单推的所有推送参数，字段路径以 "pushlist.{cid}." 为前缀。
+func validatePushList(pushList map[string]BatchPushParam) error {
+	cids := make([]string, 0, len(pushList))
+	for cid := range pushList {
+		cids = append(cids, cid)
+	}
+	sort.Strings(cids)
+
+	var errs ValidationErrors
+	for _, cid := range cids {
+		param := pushList[cid]
+		var paramErrs ValidationErrors
+		if errors.As(param.Validate(), &paramErrs) {
+			errs = append(errs, paramErrs.WithPrefix("pushlist."+cid)...)
+		}
+	}
+	return errs.Err()
+}
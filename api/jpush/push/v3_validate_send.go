@@ -44,13 +44,19 @@ func (p *apiv3) ValidateCustomSend(ctx context.Context, param interface{}) (*Sen
 	if param == nil {
 		return nil, errors.New("`param` cannot be nil")
 	}
+	param, err := p.applyClass(param)
+	if err != nil {
+		return nil, err
+	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push/validate",
-		Auth:   p.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push/validate",
+		Authorizer: p.auth,
+		Body:       param,
+
+		Idempotent: true, // 推送校验不会向用户发送任何消息
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
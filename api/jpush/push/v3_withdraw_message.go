@@ -41,10 +41,10 @@ func (p *apiv3) WithdrawMessage(ctx context.Context, msgID string) (*WithdrawMes
 	}
 
 	req := &api.Request{
-		Method: http.MethodDelete,
-		Proto:  p.proto,
-		URL:    p.host + "/v3/push/" + msgID,
-		Auth:   p.auth,
+		Method:     http.MethodDelete,
+		Proto:      p.proto,
+		URL:        p.host + "/v3/push/" + msgID,
+		Authorizer: p.auth,
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -0,0 +1,108 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+// 获取校验错误中的所有字段路径。
+func invalidFields(t *testing.T, err error) map[string]bool {
+	t.Helper()
+	var errs push.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	fields := make(map[string]bool, len(errs))
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	return fields
+}
+
+func TestSendParamValidate(t *testing.T) {
+	ttl, bad := int64(86400), int64(30*86400)
+	yes := true
+
+	if err := newParam("hello").Validate(); err != nil {
+		t.Fatalf("expected a valid param, got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		param *push.SendParam
+		field string
+	}{
+		{"missing everything", &push.SendParam{}, "platform"},
+		{"missing content", &push.SendParam{Platform: platform.All, Audience: push.BroadcastAuds}, "notification"},
+		{"invalid platform", &push.SendParam{Platform: []platform.Platform{platform.Web}, Audience: push.BroadcastAuds, Notification: &push.Notification{Alert: "hi"}}, "platform[0]"},
+		{"empty audience", &push.SendParam{Platform: platform.All, Audience: &push.Audience{}, Notification: &push.Notification{Alert: "hi"}}, "audience"},
+		{"too many tags", &push.SendParam{Platform: platform.All, Audience: &push.Audience{Tags: make([]string, 21)}, Notification: &push.Notification{Alert: "hi"}}, "audience.tag"},
+		{"mixed live activity", &push.SendParam{Platform: platform.All, Audience: &push.Audience{Tags: []string{"vip"}, LiveActivityID: "la"}, Notification: &push.Notification{Alert: "hi"}}, "audience.live_activity_id"},
+		{"hmos voip with title", &push.SendParam{Platform: platform.All, Audience: push.BroadcastAuds, Notification: &push.Notification{HMOS: &push.HmosNotification{PushType: push.HmosPushTypeVoIPCall, ExtraData: "{}", Title: "call"}}}, "notification.hmos.title"},
+		{"third with notification", &push.SendParam{Platform: platform.All, Audience: push.BroadcastAuds, Notification: &push.Notification{Alert: "hi"}, CustomMessage: &push.CustomMessage{Content: "hi"}, ThirdNotification: &push.ThirdNotificationV2{}, Options: &push.Options{Notification3rdVer: "v2"}}, "notification_3rd"},
+		{"third v2 without ver", &push.SendParam{Platform: platform.All, Audience: push.BroadcastAuds, CustomMessage: &push.CustomMessage{Content: "hi"}, ThirdNotification: &push.ThirdNotificationV2{}}, "options.notification_3rd_ver"},
+		{"apns production without ios", &push.SendParam{Platform: []platform.Platform{platform.Android}, Audience: push.BroadcastAuds, Notification: &push.Notification{Alert: "hi"}, Options: &push.Options{TimeToLive: &ttl, ApnsProduction: &yes}}, "options.apns_production"},
+		{"ttl out of range", &push.SendParam{Platform: platform.All, Audience: push.BroadcastAuds, Notification: &push.Notification{Alert: "hi"}, Options: &push.Options{TimeToLive: &bad}}, "options.time_to_live"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fields := invalidFields(t, tt.param.Validate()); !fields[tt.field] {
+				t.Errorf("expected an error on %q, got %v", tt.field, fields)
+			}
+		})
+	}
+}
+
+func TestBatchPushParamValidate(t *testing.T) {
+	param := &push.BatchPushParam{Platform: platform.All, Notification: &push.Notification{Alert: "hi"}}
+	if fields := invalidFields(t, param.Validate()); !fields["target"] || len(fields) != 1 {
+		t.Fatalf("expected only a target error, got %v", fields)
+	}
+	param.Target = "rid1"
+	if err := param.Validate(); err != nil {
+		t.Fatalf("expected a valid param, got %v", err)
+	}
+}
+
+func TestSend_ValidateBeforeSend(t *testing.T) {
+	srv, pushAPIv3 := newPushServer(t, func(cfg *sdk.Config) { cfg.ValidateBeforeSend = true })
+
+	ctx := context.Background()
+	if _, err := pushAPIv3.Send(ctx, &push.SendParam{Platform: platform.All, Audience: push.BroadcastAuds}); err == nil {
+		t.Fatal("expected a validation error")
+	}
+	_, err := pushAPIv3.BatchSendByRegistrationID(ctx, map[string]push.BatchPushParam{
+		"cid1": {Platform: platform.All, Notification: &push.Notification{Alert: "hi"}},
+	})
+	if fields := invalidFields(t, err); !fields["pushlist.cid1.target"] {
+		t.Fatalf("expected an error on pushlist.cid1.target, got %v", fields)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("expected no requests for invalid params, got %d", n)
+	}
+
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android})
+	if result, err := pushAPIv3.Send(ctx, newParam("hello")); err != nil || !result.IsSuccess() {
+		t.Fatalf("Send() = %+v, %v", result, err)
+	}
+}
@@ -17,6 +17,7 @@ package report
 import (
 	"context"
 
+	"github.com/cavlabs/jiguang-sdk-go/api"
 	"github.com/cavlabs/jiguang-sdk-go/jiguang"
 )
 
@@ -37,6 +38,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_report#%E9%80%81%E8%BE%BE%E7%BB%9F%E8%AE%A1%E8%AF%A6%E6%83%85
 	GetReceivedDetail(ctx context.Context, msgIDs []string) (*ReceivedDetailGetResult, error)
 
+	// # 送达统计详情（自动分块）
+	//  - 功能说明：获取任意数量 msgIDs 的送达统计数据，超过 100 个时自动拆分为多次 GetReceivedDetail 调用，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行，并按 `msgIDs` 的顺序合并结果。
+	//  - 部分分块失败时，返回成功分块的合并结果，以及 api.ChunkErrors 错误，其中每个失败分块的错误为请求错误或 *api.APIError。
+	GetReceivedDetailAll(ctx context.Context, msgIDs []string, opts ...api.ChunkOption) ([]ReceivedDetail, error)
+
 	// # 送达状态查询（VIP）
 	//  - 功能说明：查询已推送的一条消息在一组设备上的送达状态。
 	//  - 调用地址：POST `/v3/status/message`
@@ -51,6 +57,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_report#%E6%B6%88%E6%81%AF%E7%BB%9F%E8%AE%A1%E8%AF%A6%E6%83%85%EF%BC%88vip-%E6%96%B0%EF%BC%89
 	GetMessageDetail(ctx context.Context, msgIDs []string) (*MessageDetailGetResult, error)
 
+	// # 消息统计详情（VIP-新，自动分块）
+	//  - 功能说明：获取任意数量 msgIDs 的消息统计数据，超过 100 个时自动拆分为多次 GetMessageDetail 调用，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数执行，并按 `msgIDs` 的顺序合并结果。
+	//  - 部分分块失败时，返回成功分块的合并结果，以及 api.ChunkErrors 错误，其中每个失败分块的错误为请求错误或 *api.APIError。
+	GetMessageDetailAll(ctx context.Context, msgIDs []string, opts ...api.ChunkOption) ([]MessageDetail, error)
+
 	// # 用户统计（VIP）
 	//  - 功能说明：提供近 2 个月内某时间段的用户相关统计数据：新增用户、在线用户、活跃用户。
 	//  `start` 起始时间，它的时间单位支持：HOUR（小时，格式例：2014-06-11 09）、DAY（天，格式例：2014-06-11）、MONTH（月，格式例：2014-06）；
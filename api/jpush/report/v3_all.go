@@ -0,0 +1,76 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// # 送达统计详情（自动分块）
+//   - 功能说明：获取任意数量 msgIDs 的送达统计数据，`msgIDs` 会被拆分为每块最多 100 个，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数分别调用 GetReceivedDetail，并按 `msgIDs` 的顺序合并结果。
+//   - 部分分块失败时，返回成功分块的合并结果，以及 api.ChunkErrors 错误（其中的 Start/End 为分块在 `msgIDs` 中的位置）。
+func (r *apiv3) GetReceivedDetailAll(ctx context.Context, msgIDs []string, opts ...api.ChunkOption) ([]ReceivedDetail, error) {
+	if r == nil {
+		return nil, api.ErrNilJPushReportAPIv3
+	}
+	if len(msgIDs) == 0 {
+		return nil, errors.New("`msgIDs` cannot be empty")
+	}
+
+	chunks := make([][]ReceivedDetail, (len(msgIDs)+99)/100)
+	err := api.ForEachChunk(ctx, msgIDs, 100, func(ctx context.Context, i int, chunk []string) error {
+		result, err := r.GetReceivedDetail(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		if !result.IsSuccess() {
+			return api.NewAPIError(http.MethodGet+" /v3/received/detail", result.Response, result.Error)
+		}
+		chunks[i] = result.ReceivedDetails
+		return nil
+	}, opts...)
+	return slices.Concat(chunks...), err
+}
+
+// # 消息统计详情（VIP-新，自动分块）
+//   - 功能说明：获取任意数量 msgIDs 的消息统计数据，`msgIDs` 会被拆分为每块最多 100 个，以不超过 api.DefaultChunkConcurrency（可通过 api.WithChunkConcurrency 设置）的并发数分别调用 GetMessageDetail，并按 `msgIDs` 的顺序合并结果。
+//   - 部分分块失败时，返回成功分块的合并结果，以及 api.ChunkErrors 错误（其中的 Start/End 为分块在 `msgIDs` 中的位置）。
+func (r *apiv3) GetMessageDetailAll(ctx context.Context, msgIDs []string, opts ...api.ChunkOption) ([]MessageDetail, error) {
+	if r == nil {
+		return nil, api.ErrNilJPushReportAPIv3
+	}
+	if len(msgIDs) == 0 {
+		return nil, errors.New("`msgIDs` cannot be empty")
+	}
+
+	chunks := make([][]MessageDetail, (len(msgIDs)+99)/100)
+	err := api.ForEachChunk(ctx, msgIDs, 100, func(ctx context.Context, i int, chunk []string) error {
+		result, err := r.GetMessageDetail(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		if !result.IsSuccess() {
+			return api.NewAPIError(http.MethodGet+" /v3/messages/detail", result.Response, result.Error)
+		}
+		chunks[i] = result.MessageDetails
+		return nil
+	}, opts...)
+	return slices.Concat(chunks...), err
+}
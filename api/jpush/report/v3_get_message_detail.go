@@ -44,10 +44,10 @@ func (r *apiv3) GetMessageDetail(ctx context.Context, msgIDs []string) (*Message
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  r.proto,
-		URL:    r.host + "/v3/messages/detail?msg_ids=" + strings.Join(msgIDs, ","),
-		Auth:   r.auth,
+		Method:     http.MethodGet,
+		Proto:      r.proto,
+		URL:        r.host + "/v3/messages/detail?msg_ids=" + strings.Join(msgIDs, ","),
+		Authorizer: r.auth,
 	}
 	resp, err := r.client.Request(ctx, req)
 	if err != nil {
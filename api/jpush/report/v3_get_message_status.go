@@ -47,10 +47,10 @@ func (r *apiv3) GetMessageStatus(ctx context.Context, msgID string, registration
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  r.proto,
-		URL:    r.host + "/v3/status/message",
-		Auth:   r.auth,
+		Method:     http.MethodPost,
+		Proto:      r.proto,
+		URL:        r.host + "/v3/status/message",
+		Authorizer: r.auth,
 		Body: &messageStatusGetParam{
 			MsgID:           msgID,
 			RegistrationIDs: registrationIDs,
@@ -46,10 +46,10 @@ func (r *apiv3) GetReceivedDetail(ctx context.Context, msgIDs []string) (*Receiv
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  r.proto,
-		URL:    r.host + "/v3/received/detail?msg_ids=" + strings.Join(msgIDs, ","),
-		Auth:   r.auth,
+		Method:     http.MethodGet,
+		Proto:      r.proto,
+		URL:        r.host + "/v3/received/detail?msg_ids=" + strings.Join(msgIDs, ","),
+		Authorizer: r.auth,
 	}
 	resp, err := r.client.Request(ctx, req)
 	if err != nil {
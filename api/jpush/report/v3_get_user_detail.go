@@ -60,10 +60,10 @@ func (r *apiv3) GetUserDetail(ctx context.Context, start jiguang.UnitTime, durat
 
 	query := "?time_unit=" + tu.String() + "&start=" + url.QueryEscape(start.Format()) + "&duration=" + strconv.Itoa(duration)
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  r.proto,
-		URL:    r.host + "/v3/users" + query,
-		Auth:   r.auth,
+		Method:     http.MethodGet,
+		Proto:      r.proto,
+		URL:        r.host + "/v3/users" + query,
+		Authorizer: r.auth,
 	}
 	resp, err := r.client.Request(ctx, req)
 	if err != nil {
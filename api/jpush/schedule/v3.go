@@ -14,7 +14,12 @@
 
 package schedule
 
-import "context"
+import (
+	"context"
+	"iter"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
 
 // # Schedule API v3
 //
@@ -72,6 +77,11 @@ type APIv3 interface {
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_push_schedule#%E8%8E%B7%E5%8F%96%E6%9C%89%E6%95%88%E7%9A%84%E5%AE%9A%E6%97%B6%E4%BB%BB%E5%8A%A1%E5%88%97%E8%A1%A8
 	GetSchedules(ctx context.Context, page int) (*SchedulesGetResult, error)
 
+	// # 遍历所有有效的定时任务
+	//  - 功能说明：按页惰性调用 GetSchedules，逐个产生当前有效的定时任务，可直接用于 range 循环；`opts` 为可选的分页选项，如 api.WithPrefetch() 启用下一页的预取。
+	//  - 拉取失败（包括业务错误，以 *api.APIError 返回）或 ctx 被取消时，迭代器产生一次错误后结束。
+	AllSchedules(ctx context.Context, opts ...api.PageOption) iter.Seq2[Schedule, error]
+
 	// # 获取定时任务详情
 	//  - 功能说明：获取当前用户指定定时任务的详细信息。
 	//	- 调用地址：GET `/v3/schedules/{scheduleID}`
@@ -0,0 +1,43 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// # 遍历所有有效的定时任务
+//   - 功能说明：按页惰性调用 GetSchedules，逐个产生当前有效（EndTime 未过期）的定时任务，可直接用于 range 循环；
+//     `opts` 为可选的分页选项，如 api.WithPrefetch() 启用下一页的预取。
+//   - 拉取失败（包括业务错误，以 *api.APIError 返回）或 ctx 被取消时，迭代器产生一次错误后结束。
+func (s *apiv3) AllSchedules(ctx context.Context, opts ...api.PageOption) iter.Seq2[Schedule, error] {
+	return api.Paginate(ctx, func(ctx context.Context, page int) ([]Schedule, bool, error) {
+		if s == nil {
+			return nil, false, api.ErrNilJPushScheduleAPIv3
+		}
+		result, err := s.GetSchedules(ctx, page)
+		if err != nil {
+			return nil, false, err
+		}
+		if !result.IsSuccess() {
+			return nil, false, api.NewAPIError(http.MethodGet+" /v3/schedules", result.Response, result.Error)
+		}
+		return result.Schedules, len(result.Schedules) > 0 && page < result.TotalPages, nil
+	}, opts...)
+}
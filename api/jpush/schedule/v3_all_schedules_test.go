@@ -0,0 +1,93 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+// 创建模拟服务，并在其中创建 n 个定时任务。
+func newScheduleServer(t *testing.T, n int) (*jiguangtest.Server, schedule.APIv3) {
+	srv := jiguangtest.NewServer()
+	t.Cleanup(srv.Close)
+	scheduleAPIv3, err := sdk.NewClient(srv.Config()).Schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		result, err := scheduleAPIv3.ScheduleSend(context.Background(), &schedule.SendParam{
+			Name:    "daily",
+			Enabled: true,
+			Trigger: &schedule.Trigger{Single: &schedule.Single{Time: jiguang.LocalDateTimeNow()}},
+			Push: &push.SendParam{
+				Platform:     platform.All,
+				Audience:     push.BroadcastAuds,
+				Notification: &push.Notification{Alert: "Hello, JPush!"},
+			},
+		})
+		if err != nil || !result.IsSuccess() {
+			t.Fatalf("ScheduleSend() = %+v, %v", result, err)
+		}
+	}
+	return srv, scheduleAPIv3
+}
+
+func TestAllSchedules(t *testing.T) {
+	srv, scheduleAPIv3 := newScheduleServer(t, 120)
+
+	seen := make(map[string]bool)
+	for s, err := range scheduleAPIv3.AllSchedules(context.Background(), api.WithPrefetch()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		seen[s.ScheduleID] = true
+	}
+	if len(seen) != 120 {
+		t.Fatalf("expected 120 distinct schedules, got %d", len(seen))
+	}
+	if n := len(srv.RequestsTo(http.MethodGet, "/v3/schedules")); n != 3 {
+		t.Fatalf("expected 3 page requests, got %d", n)
+	}
+}
+
+func TestAllSchedules_Failure(t *testing.T) {
+	srv, scheduleAPIv3 := newScheduleServer(t, 60)
+	srv.Fail(jiguangtest.Failure{Method: http.MethodGet, Path: "/v3/schedules", Code: 1000, Message: "internal error", Times: 1})
+
+	var items int
+	var lastErr error
+	for _, err := range scheduleAPIv3.AllSchedules(context.Background()) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		items++
+	}
+	var apiErr *api.APIError
+	if items != 0 || !errors.As(lastErr, &apiErr) || apiErr.CodeError == nil || apiErr.CodeError.Code != 1000 {
+		t.Fatalf("expected an API error and no items, got %d items, %v", items, lastErr)
+	}
+}
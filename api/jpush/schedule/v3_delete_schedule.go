@@ -39,10 +39,10 @@ func (s *apiv3) DeleteSchedule(ctx context.Context, scheduleID string) (*DeleteR
 	}
 
 	req := &api.Request{
-		Method: http.MethodDelete,
-		Proto:  s.proto,
-		URL:    s.host + "/v3/schedules/" + scheduleID,
-		Auth:   s.auth,
+		Method:     http.MethodDelete,
+		Proto:      s.proto,
+		URL:        s.host + "/v3/schedules/" + scheduleID,
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
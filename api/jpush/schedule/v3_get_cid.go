@@ -41,10 +41,10 @@ func (s *apiv3) GetCidForSchedulePush(ctx context.Context, count int) (*CidGetRe
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v3/push/cid?type=schedule&count=" + strconv.Itoa(count),
-		Auth:   s.auth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v3/push/cid?type=schedule&count=" + strconv.Itoa(count),
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -39,10 +39,10 @@ func (s *apiv3) GetSchedule(ctx context.Context, scheduleID string) (*ScheduleGe
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v3/schedules/" + scheduleID,
-		Auth:   s.auth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v3/schedules/" + scheduleID,
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
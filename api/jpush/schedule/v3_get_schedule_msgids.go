@@ -42,10 +42,10 @@ func (s *apiv3) GetScheduleMsgIDs(ctx context.Context, scheduleID string) (*Sche
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v3/schedules/" + scheduleID + "/msg_ids",
-		Auth:   s.auth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v3/schedules/" + scheduleID + "/msg_ids",
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -45,10 +45,10 @@ func (s *apiv3) GetSchedules(ctx context.Context, page int) (*SchedulesGetResult
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v3/schedules?page=" + strconv.Itoa(page),
-		Auth:   s.auth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v3/schedules?page=" + strconv.Itoa(page),
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/cid"
 	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/send"
 	"github.com/cavlabs/jiguang-sdk-go/jiguang"
 )
@@ -52,11 +53,13 @@ func (s *apiv3) CustomScheduleSend(ctx context.Context, param interface{}) (*Sen
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  s.proto,
-		URL:    s.host + "/v3/schedules",
-		Auth:   s.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      s.proto,
+		URL:        s.host + "/v3/schedules",
+		Authorizer: s.auth,
+		Body:       param,
+
+		Idempotent: cid.Carried(param),
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
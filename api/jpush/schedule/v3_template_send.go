@@ -61,11 +61,11 @@ func (s *apiv3) ScheduleTemplateSend(ctx context.Context, id string, params []Te
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  s.proto,
-		URL:    s.host + "/v3/push/template/schedule",
-		Auth:   s.auth,
-		Body:   &templateSendParam{ID: id, Params: params, ScheduleName: scheduleName, Trigger: trigger},
+		Method:     http.MethodPost,
+		Proto:      s.proto,
+		URL:        s.host + "/v3/push/template/schedule",
+		Authorizer: s.auth,
+		Body:       &templateSendParam{ID: id, Params: params, ScheduleName: scheduleName, Trigger: trigger},
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
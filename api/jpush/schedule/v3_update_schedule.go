@@ -48,11 +48,11 @@ func (s *apiv3) UpdateSchedule(ctx context.Context, scheduleID string, param *Up
 	}
 
 	req := &api.Request{
-		Method: http.MethodPut,
-		Proto:  s.proto,
-		URL:    s.host + "/v3/schedules/" + scheduleID,
-		Auth:   s.auth,
-		Body:   param,
+		Method:     http.MethodPut,
+		Proto:      s.proto,
+		URL:        s.host + "/v3/schedules/" + scheduleID,
+		Authorizer: s.auth,
+		Body:       param,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
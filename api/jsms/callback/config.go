@@ -18,6 +18,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/cavlabs/jiguang-sdk-go/api"
 	"github.com/cavlabs/jiguang-sdk-go/jiguang"
 )
 
@@ -38,15 +39,16 @@ const (
 
 // 回调接口服务配置。
 type config struct {
-	addr     string                // 监听地址 (如 ":8088")，默认为 ":8088"
-	path     string                // 回调路径 (如 "/callback")，默认为 "/callback"
-	logger   jiguang.Logger        // 日志打印器，用于记录回调接口服务的日志，默认为 api.DefaultJSmsLogger
-	handler  http.Handler          // HTTP Handler，可自定义处理回调请求，默认为使用 net/http 实现的一个简单的 Handler
-	flag     int8                  // 标志位，用于标记是否已经设置了自定义的回执数据回调处理器，从低位到高位分别表示：SMS_REPLY、SMS_REPORT、SMS_TEMPLATE、SMS_SIGN
-	reply    ReplyDataProcessor    // 「用户回复消息」SMS_REPLY 回执数据回调处理器，为 nil 时不处理
-	report   ReportDataProcessor   // 「短信送达状态」SMS_REPORT 回执数据回调处理器，为 nil 时不处理
-	template TemplateDataProcessor // 「模板审核结果」SMS_TEMPLATE 回执数据回调处理器，为 nil 时不处理
-	sign     SignDataProcessor     // 「签名审核结果」SMS_SIGN 回执数据回调处理器，为 nil 时不处理
+	addr        string                  // 监听地址 (如 ":8088")，默认为 ":8088"
+	path        string                  // 回调路径 (如 "/callback")，默认为 "/callback"
+	logger      jiguang.Logger          // 日志打印器，用于记录回调接口服务的日志，默认为 api.DefaultJSmsLogger
+	handler     http.Handler            // HTTP Handler，可自定义处理回调请求，默认为使用 net/http 实现的一个简单的 Handler
+	credentials api.CredentialsProvider // 凭证提供者，用于校验回调请求的签名，默认为 NewServer 传入的 appKey 和 masterSecret
+	flag        int8                    // 标志位，用于标记是否已经设置了自定义的回执数据回调处理器，从低位到高位分别表示：SMS_REPLY、SMS_REPORT、SMS_TEMPLATE、SMS_SIGN
+	reply       ReplyDataProcessor      // 「用户回复消息」SMS_REPLY 回执数据回调处理器，为 nil 时不处理
+	report      ReportDataProcessor     // 「短信送达状态」SMS_REPORT 回执数据回调处理器，为 nil 时不处理
+	template    TemplateDataProcessor   // 「模板审核结果」SMS_TEMPLATE 回执数据回调处理器，为 nil 时不处理
+	sign        SignDataProcessor       // 「签名审核结果」SMS_SIGN 回执数据回调处理器，为 nil 时不处理
 }
 
 // ---------------------------------------------------------------------------------------------------------------------
@@ -116,6 +118,27 @@ func WithLogger(logger jiguang.Logger) ConfigOption {
 
 // ---------------------------------------------------------------------------------------------------------------------
 
+// 凭证提供者配置选项。
+type credentialsProviderOption struct {
+	provider api.CredentialsProvider
+}
+
+func (o credentialsProviderOption) apply(c *config) error {
+	if o.provider == nil {
+		return errors.New("`provider` cannot be nil")
+	}
+	c.credentials = o.provider
+	return nil
+}
+
+// 自定义配置回调接口服务校验回调请求所使用的凭证提供者，默认为 NewServer 传入的 appKey 和 masterSecret。
+//   - 每个回调请求都会调用该凭证提供者，以支持密钥的热轮换，详见 api.CredentialsProvider 说明。
+func WithCredentialsProvider(provider api.CredentialsProvider) ConfigOption {
+	return credentialsProviderOption{provider}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
 // HTTP Handler 配置选项。
 type httpHandlerOption struct {
 	handler http.Handler
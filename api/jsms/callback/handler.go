@@ -20,16 +20,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
 )
 
 // 默认回调请求处理器。
 type defaultHandler struct {
-	appKey       string
-	masterSecret string
-	reply        ReplyDataProcessor
-	report       ReportDataProcessor
-	template     TemplateDataProcessor
-	sign         SignDataProcessor
+	credentials api.CredentialsProvider
+	reply       ReplyDataProcessor
+	report      ReportDataProcessor
+	template    TemplateDataProcessor
+	sign        SignDataProcessor
 }
 
 func (h defaultHandler) Callback(w http.ResponseWriter, r *http.Request) {
@@ -58,7 +59,12 @@ func (h defaultHandler) Callback(w http.ResponseWriter, r *http.Request) {
 		dataType := r.Form.Get("type")       // 通知类型
 		rawData := r.Form.Get("data")        // 通知内容，JSON 字符串，开发者可以根据 type 反序列化 data
 
-		if sha1Sign(h.appKey, h.masterSecret, nonce, timestamp) != signature {
+		creds, err := h.credentials.Credentials(r.Context())
+		if err != nil {
+			http.Error(w, "failed to get credentials", http.StatusInternalServerError)
+			return
+		}
+		if sha1Sign(creds.Key, creds.Secret, nonce, timestamp) != signature {
 			http.Error(w, "signature not match", http.StatusForbidden)
 			return
 		}
@@ -67,14 +67,17 @@ func NewServer(appKey, masterSecret string, opts ...ConfigOption) (*Server, erro
 		c.sign = loggingSignDataProcessor(p)         // 「签名审核结果」SMS_SIGN
 	}
 
+	if c.credentials == nil {
+		c.credentials = api.StaticCredentials(appKey, masterSecret)
+	}
+
 	if c.handler == nil {
 		h := defaultHandler{
-			appKey:       appKey,
-			masterSecret: masterSecret,
-			reply:        c.reply,
-			report:       c.report,
-			template:     c.template,
-			sign:         c.sign,
+			credentials: c.credentials,
+			reply:       c.reply,
+			report:      c.report,
+			template:    c.template,
+			sign:        c.sign,
 		}
 		c.handler = http.HandlerFunc(h.Callback)
 	}
@@ -101,11 +101,11 @@ func (s *apiv1) sign(ctx context.Context, signID int, param *SignCreateParam, cr
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  s.proto,
-		URL:    url,
-		Auth:   s.auth,
-		Body:   body,
+		Method:     http.MethodPost,
+		Proto:      s.proto,
+		URL:        url,
+		Authorizer: s.auth,
+		Body:       body,
 	}
 	resp, err := s.client.FormRequest(ctx, req)
 	if err != nil {
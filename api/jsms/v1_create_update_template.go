@@ -86,11 +86,11 @@ func (s *apiv1) template(ctx context.Context, tempID int64, param *TemplateCreat
 	}
 
 	req := &api.Request{
-		Method: method,
-		Proto:  s.proto,
-		URL:    url,
-		Auth:   s.auth,
-		Body:   body,
+		Method:     method,
+		Proto:      s.proto,
+		URL:        url,
+		Authorizer: s.auth,
+		Body:       body,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -39,10 +39,10 @@ func (s *apiv1) DeleteSchedule(ctx context.Context, scheduleID string) (*Schedul
 	}
 
 	req := &api.Request{
-		Method: http.MethodDelete,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/schedule/" + scheduleID,
-		Auth:   s.auth,
+		Method:     http.MethodDelete,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/schedule/" + scheduleID,
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
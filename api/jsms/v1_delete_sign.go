@@ -40,10 +40,10 @@ func (s *apiv1) DeleteSign(ctx context.Context, signID int) (*SignDeleteResult,
 	}
 
 	req := &api.Request{
-		Method: http.MethodDelete,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/sign/" + strconv.Itoa(signID),
-		Auth:   s.auth,
+		Method:     http.MethodDelete,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/sign/" + strconv.Itoa(signID),
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -36,10 +36,10 @@ func (s *apiv1) GetAppBalance(ctx context.Context) (*AppBalanceGetResult, error)
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/accounts/app",
-		Auth:   s.auth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/accounts/app",
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
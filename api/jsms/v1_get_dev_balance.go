@@ -35,15 +35,15 @@ func (s *apiv1) GetDevBalance(ctx context.Context) (*DevBalanceGetResult, error)
 	if s == nil {
 		return nil, api.ErrNilJSmsAPIv1
 	}
-	if s.devAuth == "" {
+	if s.devAuth == nil {
 		return nil, errors.New("please set the `devKey` and `devSecret` required for this API")
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/accounts/dev",
-		Auth:   s.devAuth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/accounts/dev",
+		Authorizer: s.devAuth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -40,10 +40,10 @@ func (s *apiv1) GetSchedule(ctx context.Context, scheduleID string) (*ScheduleGe
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/schedule/" + scheduleID,
-		Auth:   s.auth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/schedule/" + scheduleID,
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
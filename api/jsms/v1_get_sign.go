@@ -40,10 +40,10 @@ func (s *apiv1) GetSign(ctx context.Context, signID int) (*SignGetResult, error)
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/sign/" + strconv.Itoa(signID),
-		Auth:   s.auth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/sign/" + strconv.Itoa(signID),
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -40,10 +40,10 @@ func (s *apiv1) GetTemplate(ctx context.Context, tempID int64) (*TemplateGetResu
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/templates/" + strconv.FormatInt(tempID, 10),
-		Auth:   s.auth,
+		Method:     http.MethodGet,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/templates/" + strconv.FormatInt(tempID, 10),
+		Authorizer: s.auth,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -40,11 +40,11 @@ func (s *apiv1) SendBatchMessage(ctx context.Context, param *BatchMessageSendPar
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/messages/batch",
-		Auth:   s.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/messages/batch",
+		Authorizer: s.auth,
+		Body:       param,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -41,11 +41,11 @@ func (s *apiv1) SendBatchSchedule(ctx context.Context, param *BatchScheduleSendP
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/schedule/batch",
-		Auth:   s.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/schedule/batch",
+		Authorizer: s.auth,
+		Body:       param,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
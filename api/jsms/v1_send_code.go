@@ -39,11 +39,11 @@ func (s *apiv1) SendCode(ctx context.Context, param *CodeSendParam) (*CodeSendRe
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/codes",
-		Auth:   s.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/codes",
+		Authorizer: s.auth,
+		Body:       param,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
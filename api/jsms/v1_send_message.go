@@ -39,11 +39,11 @@ func (s *apiv1) SendMessage(ctx context.Context, param *MessageSendParam) (*Mess
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/messages",
-		Auth:   s.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/messages",
+		Authorizer: s.auth,
+		Body:       param,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
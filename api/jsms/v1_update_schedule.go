@@ -42,11 +42,11 @@ func (s *apiv1) UpdateSchedule(ctx context.Context, scheduleID string, param *Sc
 	}
 
 	req := &api.Request{
-		Method: http.MethodPut,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/schedule/" + scheduleID,
-		Auth:   s.auth,
-		Body:   param,
+		Method:     http.MethodPut,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/schedule/" + scheduleID,
+		Authorizer: s.auth,
+		Body:       param,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -42,11 +42,11 @@ func (s *apiv1) VerifyCode(ctx context.Context, msgID, code string) (*CodeVerify
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  s.proto,
-		URL:    s.host + "/v1/codes/" + msgID + "/valid",
-		Auth:   s.auth,
-		Body:   &codeVerifyParam{Code: code},
+		Method:     http.MethodPost,
+		Proto:      s.proto,
+		URL:        s.host + "/v1/codes/" + msgID + "/valid",
+		Authorizer: s.auth,
+		Body:       &codeVerifyParam{Code: code},
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/cavlabs/jiguang-sdk-go/api"
 	"github.com/cavlabs/jiguang-sdk-go/jiguang"
 )
 
@@ -43,22 +44,23 @@ const (
 
 // 回调接口服务配置。
 type config struct {
-	addr          string            // 监听地址 (如 ":8089")，默认为 ":8089"
-	path          string            // 回调路径 (如 "/callback")，默认为 "/callback"
-	logger        jiguang.Logger    // 日志打印器，用于记录回调接口服务的日志，默认为 api.DefaultJUmsLogger
-	checkAuth     bool              // 是否开启安全校验，默认开启
-	handler       http.Handler      // HTTP Handler，可自定义处理回调请求，默认为使用 net/http 实现的一个简单的 Handler
-	flag          int16             // 标志位，用于标记是否已经设置了自定义的回调数据处理器，从低位到高位分别表示：TargetValid、TargetInvalid、SentSucc、SentFail、ReceivedSucc、ReceivedFail、Click、RetractedSucc、RetractedFail
-	targetValid   DataProcessor     // 目标有效 (0) 回调数据处理器，为 nil 时不处理
-	targetInvalid DataProcessor     // 目标无效 (1) 回调数据处理器，为 nil 时不处理
-	sentSucc      DataProcessor     // 提交成功 (2) 回调数据处理器，为 nil 时不处理
-	sentFail      DataProcessor     // 提交失败 (3) 回调数据处理器，为 nil 时不处理
-	receivedSucc  DataProcessor     // 送达成功 (4) 回调数据处理器，为 nil 时不处理
-	receivedFail  DataProcessor     // 送达失败 (5) 回调数据处理器，为 nil 时不处理
-	click         DataProcessor     // 点击 (6) 回调数据处理器，为 nil 时不处理
-	retractedSucc DataProcessor     // 撤回成功 (7) 回调数据处理器，为 nil 时不处理
-	retractedFail DataProcessor     // 撤回失败 (8) 回调数据处理器，为 nil 时不处理
-	unified       DataListProcessor // 统一的回调数据列表处理器，为 nil 时不处理
+	addr          string                  // 监听地址 (如 ":8089")，默认为 ":8089"
+	path          string                  // 回调路径 (如 "/callback")，默认为 "/callback"
+	logger        jiguang.Logger          // 日志打印器，用于记录回调接口服务的日志，默认为 api.DefaultJUmsLogger
+	checkAuth     bool                    // 是否开启安全校验，默认开启
+	credentials   api.CredentialsProvider // 凭证提供者，用于安全校验，默认为 NewServer 传入的 channelKey 和 masterSecret
+	handler       http.Handler            // HTTP Handler，可自定义处理回调请求，默认为使用 net/http 实现的一个简单的 Handler
+	flag          int16                   // 标志位，用于标记是否已经设置了自定义的回调数据处理器，从低位到高位分别表示：TargetValid、TargetInvalid、SentSucc、SentFail、ReceivedSucc、ReceivedFail、Click、RetractedSucc、RetractedFail
+	targetValid   DataProcessor           // 目标有效 (0) 回调数据处理器，为 nil 时不处理
+	targetInvalid DataProcessor           // 目标无效 (1) 回调数据处理器，为 nil 时不处理
+	sentSucc      DataProcessor           // 提交成功 (2) 回调数据处理器，为 nil 时不处理
+	sentFail      DataProcessor           // 提交失败 (3) 回调数据处理器，为 nil 时不处理
+	receivedSucc  DataProcessor           // 送达成功 (4) 回调数据处理器，为 nil 时不处理
+	receivedFail  DataProcessor           // 送达失败 (5) 回调数据处理器，为 nil 时不处理
+	click         DataProcessor           // 点击 (6) 回调数据处理器，为 nil 时不处理
+	retractedSucc DataProcessor           // 撤回成功 (7) 回调数据处理器，为 nil 时不处理
+	retractedFail DataProcessor           // 撤回失败 (8) 回调数据处理器，为 nil 时不处理
+	unified       DataListProcessor       // 统一的回调数据列表处理器，为 nil 时不处理
 }
 
 // ---------------------------------------------------------------------------------------------------------------------
@@ -143,6 +145,27 @@ func WithCheckAuth(checkAuth bool) ConfigOption {
 
 // ---------------------------------------------------------------------------------------------------------------------
 
+// 凭证提供者配置选项。
+type credentialsProviderOption struct {
+	provider api.CredentialsProvider
+}
+
+func (o credentialsProviderOption) apply(c *config) error {
+	if o.provider == nil {
+		return errors.New("`provider` cannot be nil")
+	}
+	c.credentials = o.provider
+	return nil
+}
+
+// 自定义配置回调接口服务校验回调请求所使用的凭证提供者，默认为 NewServer 传入的 channelKey 和 masterSecret。
+//   - 每个回调请求都会调用该凭证提供者，以支持密钥的热轮换，详见 api.CredentialsProvider 说明。
+func WithCredentialsProvider(provider api.CredentialsProvider) ConfigOption {
+	return credentialsProviderOption{provider}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
 // HTTP Handler 配置选项。
 type httpHandlerOption struct {
 	handler http.Handler
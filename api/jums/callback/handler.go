@@ -19,12 +19,13 @@ import (
 	"io"
 	"net/http"
 	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
 )
 
 // 默认回调请求处理器。
 type defaultHandler struct {
-	channelKey    string
-	masterSecret  string
+	credentials   api.CredentialsProvider
 	checkAuth     bool
 	targetValid   DataProcessor
 	targetInvalid DataProcessor
@@ -45,11 +46,16 @@ func (h defaultHandler) Callback(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "invalid auth channel", http.StatusUnauthorized)
 			return
 		}
-		if channelKey != h.channelKey {
+		creds, err := h.credentials.Credentials(r.Context())
+		if err != nil {
+			http.Error(w, "failed to get credentials", http.StatusInternalServerError)
+			return
+		}
+		if channelKey != creds.Key {
 			http.Error(w, "channel key mismatch", http.StatusForbidden)
 			return
 		}
-		if masterSecret != h.masterSecret {
+		if masterSecret != creds.Secret {
 			http.Error(w, "master secret mismatch", http.StatusForbidden)
 			return
 		}
@@ -95,10 +95,13 @@ func NewServer(channelKey, masterSecret string, opts ...ConfigOption) (*Server,
 		c.retractedFail = p
 	}
 
+	if c.credentials == nil {
+		c.credentials = api.StaticCredentials(channelKey, masterSecret)
+	}
+
 	if c.handler == nil {
 		h := defaultHandler{
-			channelKey:    channelKey,
-			masterSecret:  masterSecret,
+			credentials:   c.credentials,
 			checkAuth:     c.checkAuth,
 			targetValid:   c.targetValid,
 			targetInvalid: c.targetInvalid,
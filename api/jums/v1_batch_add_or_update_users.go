@@ -50,20 +50,20 @@ func (u *apiv1) BatchAddOrUpdateUsers(ctx context.Context, param []UsersBatchAdd
 		return nil, errors.New("`param` cannot be nil")
 	}
 
-	var auth string
+	var auth api.Authorizer
 	if accessAuth {
-		if auth = u.accessAuth; auth == "" {
+		if auth = u.accessAuth; auth == nil {
 			return nil, errors.New("please set the `accessKey` and `accessMasterSecret` required for this API")
 		}
 	} else {
 		auth = u.auth
 	}
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/user/opt",
-		Auth:   auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/user/opt",
+		Authorizer: auth,
+		Body:       param,
 	}
 	resp, err := u.client.Request(ctx, req)
 	if err != nil {
@@ -34,7 +34,7 @@ func (u *apiv1) BatchDeleteUsers(ctx context.Context, userIDs []string) (*UsersB
 		return nil, api.ErrNilJUmsAPIv1
 	}
 
-	if u.accessAuth == "" {
+	if u.accessAuth == nil {
 		return nil, errors.New("please set the `accessKey` and `accessMasterSecret` required for this API")
 	}
 
@@ -43,11 +43,11 @@ func (u *apiv1) BatchDeleteUsers(ctx context.Context, userIDs []string) (*UsersB
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/user/delete",
-		Auth:   u.accessAuth,
-		Body:   userIDs,
+		Method:     http.MethodPost,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/user/delete",
+		Authorizer: u.accessAuth,
+		Body:       userIDs,
 	}
 	resp, err := u.client.Request(ctx, req)
 	if err != nil {
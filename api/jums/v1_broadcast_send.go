@@ -61,11 +61,11 @@ func (u *apiv1) BroadcastSend(ctx context.Context, param *BroadcastSendParam) (*
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/broadcast",
-		Auth:   u.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/broadcast",
+		Authorizer: u.auth,
+		Body:       param,
 	}
 	resp, err := u.client.Request(ctx, req)
 	if err != nil {
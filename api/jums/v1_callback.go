@@ -55,6 +55,8 @@ var (
 	WithCallbackCheckAuth = callback.WithCheckAuth
 	// 自定义配置回调接口服务的 HTTP Handler，默认为使用 net/http 实现的一个简单的 Handler。
 	WithCallbackHttpHandler = callback.WithHttpHandler
+	// 自定义配置回调接口服务校验回调请求所使用的凭证提供者，默认与 JUMS API v1 使用相同的凭证。
+	WithCallbackCredentialsProvider = callback.WithCredentialsProvider
 	// 自定义配置 目标有效 (0) 回调数据处理器。注：你的自定义处理器需要实现 CallbackDataProcessor 接口。
 	WithCallbackTargetValidDataProcessor = callback.WithTargetValidDataProcessor
 	// 自定义配置 目标无效 (1) 回调数据处理器。注：你的自定义处理器需要实现 CallbackDataProcessor 接口。
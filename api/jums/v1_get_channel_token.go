@@ -46,10 +46,10 @@ func (u *apiv1) GetChannelToken(ctx context.Context, channelType string) (*Chann
 	}
 
 	req := &api.Request{
-		Method: http.MethodGet,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/token?type=" + channelType,
-		Auth:   u.auth,
+		Method:     http.MethodGet,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/token?type=" + channelType,
+		Authorizer: u.auth,
 	}
 	resp, err := u.client.Request(ctx, req)
 	if err != nil {
@@ -40,10 +40,10 @@ func (u *apiv1) Retract(ctx context.Context, msgID string) (*RetractResult, erro
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/retract/" + msgID,
-		Auth:   u.auth,
+		Method:     http.MethodPost,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/retract/" + msgID,
+		Authorizer: u.auth,
 	}
 	resp, err := u.client.Request(ctx, req)
 	if err != nil {
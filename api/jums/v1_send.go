@@ -62,11 +62,11 @@ func (u *apiv1) Send(ctx context.Context, param *SendParam) (*SendResult, error)
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/sent",
-		Auth:   u.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/sent",
+		Authorizer: u.auth,
+		Body:       param,
 	}
 	resp, err := u.client.Request(ctx, req)
 	if err != nil {
@@ -40,11 +40,11 @@ func (u *apiv1) TemplateBroadcastSend(ctx context.Context, param *TemplateBroadc
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/template/broadcast",
-		Auth:   u.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/template/broadcast",
+		Authorizer: u.auth,
+		Body:       param,
 	}
 	resp, err := u.client.Request(ctx, req)
 	if err != nil {
@@ -41,11 +41,11 @@ func (u *apiv1) TemplateSend(ctx context.Context, param *TemplateSendParam) (*Te
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/template/sent",
-		Auth:   u.auth,
-		Body:   param,
+		Method:     http.MethodPost,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/template/sent",
+		Authorizer: u.auth,
+		Body:       param,
 	}
 	resp, err := u.client.Request(ctx, req)
 	if err != nil {
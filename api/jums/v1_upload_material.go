@@ -63,11 +63,11 @@ func (u *apiv1) UploadMaterial(ctx context.Context, param *MaterialUploadParam)
 	}
 
 	req := &api.Request{
-		Method: http.MethodPost,
-		Proto:  u.proto,
-		URL:    u.host + "/v1/material",
-		Auth:   u.auth,
-		Body:   body,
+		Method:     http.MethodPost,
+		Proto:      u.proto,
+		URL:        u.host + "/v1/material",
+		Authorizer: u.auth,
+		Body:       body,
 	}
 	resp, err := u.client.FormRequest(ctx, req)
 	if err != nil {
@@ -0,0 +1,55 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// 处理极光 REST API 请求并返回响应的函数。
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// # 请求/响应中间件
+//
+// 中间件包裹在 HttpClient 发送的每一个 API 请求外层（在重试和频率控制之外，即一次 API 调用只经过一次中间件），可用于：
+//   - 检查和修改即将发送的请求，如注入自定义请求头、审计请求内容等；
+//   - 不调用 `next`，直接返回一个合成的响应，实现短路，如故障注入、本地缓存等；返回的响应和错误同时为 nil 时，请求以错误结束；
+//   - 在调用 `next` 前后观察响应结果和耗时，如采集指标等。
+//
+// 多个中间件按照设置的顺序由外向内依次执行，即第一个中间件最先收到请求、最后收到响应。
+type Middleware func(next Handler) Handler
+
+// 将中间件列表依次包裹在 `h` 的外层。
+func chainMiddlewares(h Handler, middlewares []Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			h = middlewares[i](h)
+		}
+	}
+	return h
+}
+
+// 创建一个用于观察 API 调用结果和耗时的中间件，`observe` 会在每次 API 调用结束后被调用。
+func ObserverMiddleware(observe func(ctx context.Context, req *Request, resp *Response, err error, latency time.Duration)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			startTime := time.Now()
+			resp, err := next(ctx, req)
+			observe(ctx, req, resp, err, time.Since(startTime))
+			return resp, err
+		}
+	}
+}
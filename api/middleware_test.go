@@ -0,0 +1,96 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+func TestMiddlewares(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("X-Trace-Id")))
+	}))
+	defer srv.Close()
+
+	var order []string
+	injectHeader := func(next api.Handler) api.Handler {
+		return func(ctx context.Context, req *api.Request) (*api.Response, error) {
+			order = append(order, "inject")
+			if req.Header == nil {
+				req.Header = http.Header{}
+			}
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return next(ctx, req)
+		}
+	}
+	shortCircuit := func(next api.Handler) api.Handler {
+		return func(ctx context.Context, req *api.Request) (*api.Response, error) {
+			order = append(order, "fault")
+			if strings.HasSuffix(req.URL, "/fault") {
+				return &api.Response{StatusCode: http.StatusServiceUnavailable, RawBody: []byte(`{"error":{"code":1000}}`)}, nil
+			}
+			return next(ctx, req)
+		}
+	}
+	var observed []int
+	observer := api.ObserverMiddleware(func(_ context.Context, _ *api.Request, resp *api.Response, _ error, _ time.Duration) {
+		observed = append(observed, resp.StatusCode)
+	})
+
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithMiddlewares(observer, injectHeader, shortCircuit))
+
+	resp, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.RawBody) != "trace-1" {
+		t.Errorf("body = %q, want injected header value", resp.RawBody)
+	}
+
+	resp, err = client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL + "/fault"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want synthetic 503", resp.StatusCode)
+	}
+
+	if got := strings.Join(order, ","); got != "inject,fault,inject,fault" {
+		t.Errorf("order = %s", got)
+	}
+	if len(observed) != 2 || observed[0] != http.StatusOK || observed[1] != http.StatusServiceUnavailable {
+		t.Errorf("observed = %v", observed)
+	}
+}
+
+func TestMiddlewareNilResponse(t *testing.T) {
+	nilResponse := func(api.Handler) api.Handler {
+		return func(context.Context, *api.Request) (*api.Response, error) {
+			return nil, nil
+		}
+	}
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithMiddlewares(nilResponse))
+	resp, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: "http://127.0.0.1/unused"})
+	if err == nil || resp != nil {
+		t.Fatalf("Request() = %v, %v, want an error for the nil response", resp, err)
+	}
+}
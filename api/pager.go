@@ -0,0 +1,112 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// 分页拉取函数：获取第 page 页（从 1 开始）的记录，并返回是否还有下一页。
+type PageFetcher[T any] func(ctx context.Context, page int) (items []T, more bool, err error)
+
+// 分页迭代器的配置选项。
+type PageOption func(*pageOptions)
+
+type pageOptions struct {
+	prefetch bool
+}
+
+// 启用预取：在调用方处理当前页的记录时，提前在后台拉取下一页。
+//   - 迭代提前结束时，正在进行的预取请求会被取消。
+func WithPrefetch() PageOption {
+	return func(o *pageOptions) {
+		o.prefetch = true
+	}
+}
+
+// 创建按页惰性拉取记录的迭代器，可直接用于 range 循环：
+//
+//	for item, err := range api.Paginate(ctx, fetch) {
+//		if err != nil {
+//			return err
+//		}
+//		// 处理 item
+//	}
+//
+// 注意事项：
+//   - 只有在迭代到需要时才会拉取下一页（启用 WithPrefetch 时提前一页）；
+//   - 拉取失败或 ctx 被取消时，迭代器产生一次零值记录和对应的错误后结束；
+//   - 调用方提前结束 range 循环时，不会再拉取后续的页。
+func Paginate[T any](ctx context.Context, fetch PageFetcher[T], opts ...PageOption) iter.Seq2[T, error] {
+	var o pageOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		next := fetchPage(ctx, fetch, 1, false)
+		for page := 1; ; page++ {
+			var zero T
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			p := next()
+			if p.err != nil {
+				yield(zero, p.err)
+				return
+			}
+			if p.more {
+				next = fetchPage(ctx, fetch, page+1, o.prefetch)
+			}
+			for _, item := range p.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !p.more {
+				return
+			}
+		}
+	}
+}
+
+type pageResult[T any] struct {
+	items []T
+	more  bool
+	err   error
+}
+
+// 拉取第 page 页，返回获取结果的函数；async 为 true 时立即在后台拉取。
+func fetchPage[T any](ctx context.Context, fetch PageFetcher[T], page int, async bool) func() pageResult[T] {
+	if !async {
+		return func() pageResult[T] {
+			items, more, err := fetch(ctx, page)
+			return pageResult[T]{items, more, err}
+		}
+	}
+	ch := make(chan pageResult[T], 1) // 带缓冲，迭代提前结束时后台拉取也能正常退出
+	go func() {
+		items, more, err := fetch(ctx, page)
+		ch <- pageResult[T]{items, more, err}
+	}()
+	return func() pageResult[T] {
+		return <-ch
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// 返回共 total 条记录、每页 size 条的分页拉取函数，并记录拉取的页数。
+func newFetcher(total, size int, calls *int32) api.PageFetcher[int] {
+	return func(ctx context.Context, page int) ([]int, bool, error) {
+		atomic.AddInt32(calls, 1)
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+		var items []int
+		for i := (page - 1) * size; i < min(page*size, total); i++ {
+			items = append(items, i)
+		}
+		return items, page*size < total, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	for _, opts := range [][]api.PageOption{nil, {api.WithPrefetch()}} {
+		var calls int32
+		var got []int
+		for item, err := range api.Paginate(context.Background(), newFetcher(25, 10, &calls), opts...) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, item)
+		}
+		if len(got) != 25 || got[24] != 24 {
+			t.Fatalf("unexpected items: %v", got)
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 page fetches, got %d", calls)
+		}
+	}
+}
+
+func TestPaginate_StopEarly(t *testing.T) {
+	var calls int32
+	for item := range api.Paginate(context.Background(), newFetcher(100, 10, &calls)) {
+		if item == 4 {
+			break
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected pages to be fetched lazily, got %d fetches", calls)
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	errBoom := errors.New("boom")
+	fetch := func(_ context.Context, page int) ([]int, bool, error) {
+		if page == 2 {
+			return nil, false, errBoom
+		}
+		return []int{1, 2}, true, nil
+	}
+
+	var items int
+	var lastErr error
+	for _, err := range api.Paginate(context.Background(), fetch) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		items++
+	}
+	if items != 2 || !errors.Is(lastErr, errBoom) {
+		t.Fatalf("expected 2 items then the fetch error, got %d items, %v", items, lastErr)
+	}
+}
+
+func TestPaginate_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls int32
+
+	var lastErr error
+	for item, err := range api.Paginate(ctx, newFetcher(100, 10, &calls)) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		if item == 0 {
+			cancel()
+		}
+	}
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", lastErr)
+	}
+	if calls != 1 {
+		t.Fatalf("expected no page fetches after cancellation, got %d fetches", calls)
+	}
+}
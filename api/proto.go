@@ -0,0 +1,163 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// 默认的 HTTP 协议版本，在协议版本探测完成之前或探测失败时使用。
+const defaultProto = "HTTP/1.1"
+
+// 探测 HTTP 协议版本的超时时长，探测请求在此时长内未完成时视为探测失败。
+var DetectProtoTimeout = 5 * time.Second
+
+// 探测失败后，再次探测同一 Host 前需要等待的时长，避免在网络不可用时频繁发起探测请求。
+const detectProtoRetryInterval = time.Minute
+
+// 进程内全局共享的 HTTP 协议版本缓存，按发出探测请求的 Client 和 Host 缓存探测结果。
+var protos = &protoCache{
+	protos:    make(map[protoCacheKey]string),
+	detecting: make(map[protoCacheKey]chan struct{}),
+	failedAt:  make(map[protoCacheKey]time.Time),
+}
+
+// 协议版本缓存键：探测请求经由 owner 对应的 Client 发出，不同的 Client（如是否支持 HTTP/2）可能探测到不同的结果。
+type protoCacheKey struct {
+	owner interface{}
+	host  string
+}
+
+type protoCache struct {
+	mu        sync.Mutex
+	protos    map[protoCacheKey]string        // 已探测到的协议版本
+	detecting map[protoCacheKey]chan struct{} // 正在进行的探测，探测结束后关闭
+	failedAt  map[protoCacheKey]time.Time     // 最近一次探测失败的时间
+}
+
+// 获取 URL 对应 Host 的协议版本缓存键，如 "https://api.jpush.cn"。
+func protoKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// 获取协议版本缓存的归属：Client 为指针时，使用同一 Client 的 HttpClient 共享探测结果，否则仅在当前 HttpClient 内共享。
+func protoOwner(lc *loggingHttpClient) interface{} {
+	if v := reflect.ValueOf(lc.client); v.Kind() == reflect.Pointer && !v.IsNil() {
+		return lc.client
+	}
+	return lc
+}
+
+// 获取已缓存的协议版本，或尝试开始探测：
+//   - 已有缓存时返回缓存的协议版本；
+//   - 已经在探测中时返回 `done`，它会在该探测结束后被关闭；
+//   - 距离上次探测失败的时间过短时，三个返回值均为零值；
+//   - 否则开始探测并返回 `started` 为 true，调用方须在探测结束后调用 abort 或 end。
+func (c *protoCache) begin(key protoCacheKey) (proto string, done <-chan struct{}, started bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if proto, ok := c.protos[key]; ok {
+		return proto, nil, false
+	}
+	if ch, ok := c.detecting[key]; ok {
+		return "", ch, false
+	}
+	if failedAt, ok := c.failedAt[key]; ok && time.Since(failedAt) < detectProtoRetryInterval {
+		return "", nil, false
+	}
+	c.detecting[key] = make(chan struct{})
+	return "", nil, true
+}
+
+// 放弃探测，不记录结果，之后可立即再次探测。
+func (c *protoCache) abort(key protoCacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.finish(key)
+}
+
+// 结束探测并记录结果，`proto` 为空表示探测失败。
+func (c *protoCache) end(key protoCacheKey, proto string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if proto == "" {
+		c.failedAt[key] = time.Now()
+	} else {
+		delete(c.failedAt, key)
+		c.protos[key] = proto
+	}
+	c.finish(key)
+}
+
+// 唤醒等待该探测的调用方，调用前须持有锁。
+func (c *protoCache) finish(key protoCacheKey) {
+	if ch, ok := c.detecting[key]; ok {
+		close(ch)
+		delete(c.detecting, key)
+	}
+}
+
+// 使用 HEAD 请求探测给定 URL 对应服务器支持的 HTTP 协议版本，探测失败时返回空字符串。
+func (lc *loggingHttpClient) headProto(ctx context.Context, url string) string {
+	ctx, cancel := context.WithTimeout(ctx, DetectProtoTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return ""
+	}
+	resp, err := lc.client.Do(req)
+	if err != nil {
+		return ""
+	}
+	_ = resp.Body.Close()
+	return resp.Proto
+}
+
+// 探测 Host 的协议版本并记录结果，调用前须通过 protoCache.begin 开始探测；`ctx` 被取消时放弃探测并返回空字符串。
+func (lc *loggingHttpClient) probe(ctx context.Context, key protoCacheKey) string {
+	proto := lc.headProto(ctx, key.host)
+	if proto == "" && ctx.Err() != nil {
+		protos.abort(key)
+		return ""
+	}
+	protos.end(key, proto)
+	return proto
+}
+
+// 非阻塞地获取给定 URL 对应服务器的 HTTP 协议版本。
+//
+// 如果尚未缓存，则在后台发起一次探测（同一 Host 同时只会有一个探测请求），并立即返回默认的 "HTTP/1.1"；
+// 后台探测使用 WithProbeContext 设置的上下文，且耗时不超过 DetectProtoTimeout。
+func (lc *loggingHttpClient) lazyProto(url string) string {
+	key := protoCacheKey{owner: lc.protoOwner, host: protoKey(url)}
+	proto, _, started := protos.begin(key)
+	if proto != "" {
+		return proto
+	}
+	if started {
+		go lc.probe(lc.probeCtx, key)
+	}
+	return defaultProto
+}
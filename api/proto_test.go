@@ -0,0 +1,207 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+)
+
+func TestDetectProtoCached(t *testing.T) {
+	var heads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			atomic.AddInt32(&heads, 1)
+		}
+	}))
+	defer srv.Close()
+
+	client1 := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+	client2 := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+	if proto := client1.DetectProto(srv.URL + "/v3/push"); proto != "HTTP/1.1" {
+		t.Errorf("proto = %q, want HTTP/1.1", proto)
+	}
+	client2.DetectProto(srv.URL + "/v3/devices")
+	if _, err := client2.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&heads); got != 1 {
+		t.Errorf("HEAD requests = %d, want 1", got)
+	}
+}
+
+func TestDetectProtoLazy(t *testing.T) {
+	var heads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			atomic.AddInt32(&heads, 1)
+		}
+	}))
+	defer srv.Close()
+
+	// Build 不应发起任何网络请求，即使 Host 不可达。
+	if _, err := device.NewAPIv3Builder().SetHost("http://127.0.0.1:1").SetAppKey("k").SetMasterSecret("s").Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 显式设置协议版本时跳过探测。
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+	if _, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, Proto: "HTTP/1.1", URL: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&heads); got != 0 {
+		t.Errorf("HEAD requests = %d, want 0", got)
+	}
+
+	// 未设置时在后台探测，请求本身不等待探测完成。
+	for i := 0; i < 3; i++ {
+		if _, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&heads) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&heads); got != 1 {
+		t.Errorf("HEAD requests = %d, want 1", got)
+	}
+}
+
+// 第一个 HEAD 请求一直阻塞到请求被取消，之后的 HEAD 请求立即返回。
+func newBlockingProbeServer(t *testing.T) (*httptest.Server, *int32) {
+	var heads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead && atomic.AddInt32(&heads, 1) == 1 {
+			<-r.Context().Done()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &heads
+}
+
+func TestDetectProtoContextCanceled(t *testing.T) {
+	srv, heads := newBlockingProbeServer(t)
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	detector, ok := client.(api.ProtoContextDetector)
+	if !ok {
+		t.Fatal("HttpClient does not implement ProtoContextDetector")
+	}
+	if proto := detector.DetectProtoContext(ctx, srv.URL); proto != "HTTP/1.1" {
+		t.Errorf("proto = %q, want HTTP/1.1", proto)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("canceled DetectProtoContext took %v", elapsed)
+	}
+
+	// 被取消的探测不记为失败，可以立即再次探测。
+	client.DetectProto(srv.URL)
+	if got := atomic.LoadInt32(heads); got != 2 {
+		t.Errorf("HEAD requests = %d, want 2", got)
+	}
+}
+
+func TestDetectProtoLazyCanceled(t *testing.T) {
+	srv, heads := newBlockingProbeServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithProbeContext(ctx))
+
+	if _, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(heads) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 取消后后台探测被中止，之后的探测会重新发起。
+	cancel()
+	for atomic.LoadInt32(heads) < 2 && time.Now().Before(deadline) {
+		client.DetectProto(srv.URL)
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(heads); got != 2 {
+		t.Errorf("HEAD requests = %d, want 2", got)
+	}
+}
+
+// 以给定的协议版本响应 HEAD 请求的 Client，每个请求在 release 被关闭之前一直阻塞。
+type protoClient struct {
+	proto   string
+	heads   int32
+	release chan struct{}
+}
+
+func (c *protoClient) Do(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.heads, 1)
+	<-c.release
+	return &http.Response{StatusCode: http.StatusOK, Proto: c.proto, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+}
+
+func TestDetectProtoWaitsForInflightProbe(t *testing.T) {
+	pc := &protoClient{proto: "HTTP/2.0", release: make(chan struct{})}
+	client := api.NewHttpClient(pc, nil, api.HttpLogLevelNone)
+
+	const callers = 5
+	results := make(chan string, callers)
+	for i := 0; i < callers; i++ {
+		go func() { results <- client.DetectProto("https://wait.example.com/v3/push") }()
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&pc.heads) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond) // 让其他调用方进入等待
+	close(pc.release)
+
+	for i := 0; i < callers; i++ {
+		if proto := <-results; proto != "HTTP/2.0" {
+			t.Errorf("proto = %q, want HTTP/2.0", proto)
+		}
+	}
+	if got := atomic.LoadInt32(&pc.heads); got != 1 {
+		t.Errorf("HEAD requests = %d, want 1", got)
+	}
+}
+
+func TestDetectProtoPerClient(t *testing.T) {
+	h2 := &protoClient{proto: "HTTP/2.0", release: make(chan struct{})}
+	h1 := &protoClient{proto: "HTTP/1.1", release: make(chan struct{})}
+	close(h2.release)
+	close(h1.release)
+
+	const url = "https://per-client.example.com/v3/push"
+	if proto := api.NewHttpClient(h2, nil, api.HttpLogLevelNone).DetectProto(url); proto != "HTTP/2.0" {
+		t.Errorf("proto = %q, want HTTP/2.0", proto)
+	}
+	if proto := api.NewHttpClient(h1, nil, api.HttpLogLevelNone).DetectProto(url); proto != "HTTP/1.1" {
+		t.Errorf("proto = %q, want HTTP/1.1", proto)
+	}
+	// 使用同一 Client 的 HttpClient 共享探测结果。
+	if proto := api.NewHttpClient(h2, nil, api.HttpLogLevelNone).DetectProto(url); proto != "HTTP/2.0" || atomic.LoadInt32(&h2.heads) != 1 {
+		t.Errorf("proto = %q, HEAD requests = %d, want HTTP/2.0 and 1", proto, h2.heads)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 当需要等待的时长超过 RateLimiter 允许的最长等待时长时返回的错误，属于 ErrRateLimited 类别。
+var ErrRateLimitWaitExceeded = fmt.Errorf("%w: wait time exceeds the max wait", ErrRateLimited)
+
+// # 客户端频率限制器
+//
+// 根据 API 返回的 `X-Rate-Limit-*` 响应头（详见 Rate），在客户端主动进行频率控制，按 Host 和应用标识（appKey 等鉴权标识）分别计数：
+//   - 当前时间窗口的剩余可用次数耗尽时，后续请求会排队等待，并均匀分布到之后的时间窗口中发送，而不是直接失败并返回 429；
+//   - 时间窗口重置后，在收到新的响应头之前，仍按上一次得知的配额和窗口时长继续计数；
+//   - 放弃等待（超过最长等待时长或上下文被取消）的请求会归还其预留的配额；
+//   - 可选地通过 WithTokenBucket 配置令牌桶预限流，用于平滑突发流量，为其它共享同一配额的调用方预留余量。
+//
+// 同一个 RateLimiter 可以被多个 API 共享（如批处理任务和在线业务使用同一个 appKey 时），以便共同遵守同一配额。
+type RateLimiter struct {
+	mu      sync.Mutex
+	limits  map[string]*rateLimit
+	qps     float64       // 令牌桶每秒生成的令牌数，为 0 时不启用令牌桶预限流
+	burst   int           // 令牌桶容量
+	maxWait time.Duration // 单次请求的最长等待时长，为 0 时表示不限制（直到上下文被取消）
+}
+
+// 单个 Host + 应用标识的频率控制状态。
+type rateLimit struct {
+	limit     int           // 每个时间窗口的可用次数，0 表示未知
+	window    time.Duration // 时间窗口的时长（取观察到的最大重置时长），0 表示未知
+	remaining int           // 当前时间窗口的剩余可用次数（已扣减排队中的请求，为负数时表示有请求排队等待之后的时间窗口）
+	resetAt   time.Time     // 当前时间窗口的重置时间，零值表示未知
+	tokens    float64       // 令牌桶当前的令牌数
+	last      time.Time     // 令牌桶上一次更新的时间
+}
+
+// 一次请求预留的配额。
+type rateReservation struct {
+	key    string
+	delay  time.Duration // 发送前需要等待的时长
+	token  bool          // 是否预留了令牌桶的令牌
+	window bool          // 是否预留了时间窗口的配额
+}
+
+func NewRateLimiter(opts ...RateLimiterOption) *RateLimiter {
+	rl := &RateLimiter{limits: make(map[string]*rateLimit)}
+	for _, opt := range opts {
+		opt(rl)
+	}
+	return rl
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 用于配置 RateLimiter 的选项。
+type RateLimiterOption func(*RateLimiter)
+
+// 启用令牌桶预限流，`qps` 为每秒允许的请求数，`burst` 为允许的突发请求数（小于 1 时使用 1）。
+func WithTokenBucket(qps float64, burst int) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		rl.qps = qps
+		rl.burst = max(burst, 1)
+	}
+}
+
+// 自定义设置单次请求的最长等待时长，超过时返回 ErrRateLimitWaitExceeded，默认为 0，即一直等待直到上下文被取消。
+func WithMaxWait(maxWait time.Duration) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		rl.maxWait = maxWait
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 等待直到请求 `req` 被允许发送，或上下文被取消；放弃等待时归还预留的配额。
+func (rl *RateLimiter) wait(ctx context.Context, req *http.Request) (time.Duration, error) {
+	if rl == nil {
+		return 0, nil
+	}
+
+	r := rl.reserve(rateLimitKey(req), time.Now())
+	if r.delay <= 0 {
+		return 0, nil
+	}
+	if rl.maxWait > 0 && r.delay > rl.maxWait {
+		rl.release(r)
+		return r.delay, ErrRateLimitWaitExceeded
+	}
+	if err := sleepContext(ctx, r.delay); err != nil {
+		rl.release(r)
+		return r.delay, err
+	}
+	return r.delay, nil
+}
+
+// 为一次请求预留配额，并计算在发送前需要等待的时长。
+func (rl *RateLimiter) reserve(key string, now time.Time) rateReservation {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	l, ok := rl.limits[key]
+	if !ok {
+		l = &rateLimit{tokens: float64(rl.burst), last: now}
+		rl.limits[key] = l
+	}
+	r := rateReservation{key: key}
+
+	// 令牌桶预限流
+	if rl.qps > 0 {
+		l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*rl.qps, float64(rl.burst))
+		l.last = now
+		l.tokens--
+		r.token = true
+		if l.tokens < 0 {
+			r.delay = time.Duration(-l.tokens / rl.qps * float64(time.Second))
+		}
+	}
+
+	// 服务端时间窗口配额
+	l.advance(now)
+	if !l.resetAt.IsZero() {
+		l.remaining--
+		r.window = true
+		if l.remaining < 0 {
+			r.delay = max(r.delay, l.slot(-l.remaining-1).Sub(now))
+		}
+	}
+
+	return r
+}
+
+// 归还放弃等待的请求所预留的配额。
+func (rl *RateLimiter) release(r rateReservation) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	l, ok := rl.limits[r.key]
+	if !ok {
+		return
+	}
+	if r.token {
+		l.tokens = min(l.tokens+1, float64(rl.burst))
+	}
+	if r.window && !l.resetAt.IsZero() {
+		l.remaining++
+		if l.limit > 0 {
+			l.remaining = min(l.remaining, l.limit)
+		}
+	}
+}
+
+// 时间窗口重置后，按已知的配额和窗口时长进入下一个时间窗口；配额或窗口时长未知时，等待下一次响应更新。
+func (l *rateLimit) advance(now time.Time) {
+	if l.resetAt.IsZero() || now.Before(l.resetAt) {
+		return
+	}
+	if l.limit <= 0 || l.window <= 0 {
+		l.resetAt = time.Time{}
+		return
+	}
+	for !now.Before(l.resetAt) {
+		l.resetAt = l.resetAt.Add(l.window)
+		l.remaining = min(l.remaining+l.limit, l.limit) // 排队中的请求占用新时间窗口的配额
+	}
+}
+
+// 获取第 `n` 个（从 0 开始）排队等待的请求的发送时间，排队的请求均匀分布在当前时间窗口之后的各个时间窗口中。
+func (l *rateLimit) slot(n int) time.Time {
+	if l.limit <= 0 || l.window <= 0 {
+		return l.resetAt
+	}
+	windows, pos := n/l.limit, n%l.limit
+	return l.resetAt.Add(time.Duration(windows)*l.window + time.Duration(pos)*l.window/time.Duration(l.limit))
+}
+
+// 根据响应的频率控制信息更新状态。
+func (rl *RateLimiter) update(req *http.Request, resp *Response) {
+	if rl == nil || resp == nil {
+		return
+	}
+	rate := resp.Rate
+	if rate.Limit <= 0 && resp.StatusCode != http.StatusTooManyRequests {
+		return // 响应未携带频率控制信息
+	}
+
+	remaining, reset := rate.Remaining, time.Duration(rate.Reset)*time.Second
+	if resp.StatusCode == http.StatusTooManyRequests {
+		remaining = 0
+		if wait, ok := resp.retryAfter(); ok {
+			reset = wait
+		}
+	}
+	if reset <= 0 {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	key := rateLimitKey(req)
+	l, ok := rl.limits[key]
+	if !ok {
+		l = &rateLimit{tokens: float64(rl.burst), last: now}
+		rl.limits[key] = l
+	}
+	if rate.Limit > 0 {
+		l.limit = rate.Limit
+		l.window = max(l.window, time.Duration(rate.Reset)*time.Second)
+	}
+	l.advance(now)
+	queued := max(-l.remaining, 0) // 仍在排队等待之后时间窗口的请求继续占用配额
+	l.remaining = remaining - queued
+	l.resetAt = now.Add(reset)
+}
+
+// 频率控制的计数键，由 Host 和 Basic 鉴权中的标识（如 appKey、devKey 等）组成，不包含密钥。
+func rateLimitKey(req *http.Request) string {
+	key := req.URL.Host
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Basic ") {
+		if creds, err := base64.StdEncoding.DecodeString(auth[len("Basic "):]); err == nil {
+			if i := strings.IndexByte(string(creds), ':'); i >= 0 {
+				key += "/" + string(creds[:i])
+			}
+		}
+	}
+	return key
+}
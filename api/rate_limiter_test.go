@@ -0,0 +1,156 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+func TestRateLimiterWindow(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rate-Limit-Limit", "600")
+		w.Header().Set("X-Rate-Limit-Remaining", "0")
+		w.Header().Set("X-Rate-Limit-Reset", "60")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	limiter := api.NewRateLimiter(api.WithMaxWait(time.Second))
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithRateLimiter(limiter))
+	ctx := context.Background()
+
+	// 第一次请求耗尽了时间窗口的配额，同一 appKey 的下一次请求需要等待窗口重置。
+	if _, err := client.Request(ctx, &api.Request{Method: http.MethodGet, URL: srv.URL, Auth: "Basic YTpi"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Request(ctx, &api.Request{Method: http.MethodGet, URL: srv.URL, Auth: "Basic YTpi"}); !errors.Is(err, api.ErrRateLimitWaitExceeded) {
+		t.Fatalf("err = %v, want ErrRateLimitWaitExceeded", err)
+	}
+
+	// 其它 appKey 不受影响。
+	if _, err := client.Request(ctx, &api.Request{Method: http.MethodGet, URL: srv.URL, Auth: "Basic Yzpk"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRateLimiterSpreadsWaiters(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead { // 忽略协议探测请求
+			mu.Lock()
+			times = append(times, time.Now())
+			mu.Unlock()
+		}
+		w.Header().Set("X-Rate-Limit-Limit", "4")
+		w.Header().Set("X-Rate-Limit-Remaining", "0")
+		w.Header().Set("X-Rate-Limit-Reset", "1")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithRateLimiter(api.NewRateLimiter()))
+	ctx := context.Background()
+	if _, err := client.Request(ctx, &api.Request{Method: http.MethodGet, URL: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 排队的 4 个请求应均匀分布在下一个时间窗口中，而不是在窗口重置时同时发送。
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Request(ctx, &api.Request{Method: http.MethodGet, URL: srv.URL}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	queued := times[1:] // 服务端按到达顺序记录
+	if spread := queued[len(queued)-1].Sub(queued[0]); spread < 600*time.Millisecond {
+		t.Errorf("queued requests were sent within %v, want them spread over the next window", spread)
+	}
+}
+
+func TestRateLimiterKeepsWindowAfterReset(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead && calls.Add(1) == 1 { // 仅第一次响应携带频率控制信息，忽略协议探测请求
+			w.Header().Set("X-Rate-Limit-Limit", "1")
+			w.Header().Set("X-Rate-Limit-Remaining", "0")
+			w.Header().Set("X-Rate-Limit-Reset", "1")
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	limiter := api.NewRateLimiter(api.WithMaxWait(500 * time.Millisecond))
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithRateLimiter(limiter))
+	ctx := context.Background()
+	req := func() error {
+		_, err := client.Request(ctx, &api.Request{Method: http.MethodGet, URL: srv.URL})
+		return err
+	}
+
+	if err := req(); err != nil {
+		t.Fatal(err)
+	}
+	if err := req(); !errors.Is(err, api.ErrRateLimitWaitExceeded) {
+		t.Fatalf("err = %v, want ErrRateLimitWaitExceeded", err)
+	}
+
+	// 放弃等待的请求归还了配额，窗口重置后的第一个请求无需等待。
+	time.Sleep(1100 * time.Millisecond)
+	if err := req(); err != nil {
+		t.Fatalf("first request of the next window: %v", err)
+	}
+	// 未收到新的响应头时，仍按上一次得知的配额继续限制。
+	if err := req(); !errors.Is(err, api.ErrRateLimitWaitExceeded) {
+		t.Fatalf("err = %v, want ErrRateLimitWaitExceeded", err)
+	}
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	limiter := api.NewRateLimiter(api.WithTokenBucket(50, 1))
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithRateLimiter(limiter))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("3 requests at 50 qps with burst 1 took %v, want at least 40ms", elapsed)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"mime/multipart"
 	"net/http"
 
@@ -32,6 +33,12 @@ type Request struct {
 	Auth   string      // 请求授权信息
 	Header http.Header // 自定义请求头
 	Body   interface{} // 请求正文负载
+	// 是否为幂等请求，幂等请求在遇到网络错误或 5xx 服务端错误时可按重试策略安全重试。
+	//  - GET、HEAD、OPTIONS、DELETE 请求总是被视为幂等的，无需设置；
+	//  - 携带了 CID 的推送请求在服务端会被去重，也可视为幂等的。
+	Idempotent bool
+	// 请求授权信息的生成器，设置后将在每次发送请求时重新生成 Auth，以支持凭证的热轮换。
+	Authorizer Authorizer
 }
 
 // defaultUserAgent 是默认的用户代理字符串，用于携带的请求头 `User-Agent` 标识。
@@ -65,21 +72,34 @@ func newApplicationJSONRequest(ctx context.Context, req *Request) (*http.Request
 }
 
 // newMultipartFormDataRequest 创建一个带有多部分表单数据正文负载的 HTTP 请求。
+//
+// 正文通过 io.Pipe 以流式的方式边生成边发送，而不是将全部文件内容缓冲在内存中；
+// 当全部文件大小已知时，会设置正确的 Content-Length；当全部文件均通过文件路径指定时，正文可被重新生成以便重试。
 func newMultipartFormDataRequest(ctx context.Context, req *Request) (*http.Request, error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+	body := req.Body.(MultipartFormDataBody)
+	boundary := multipart.NewWriter(nil).Boundary()
 
-	if err := req.Body.(MultipartFormDataBody).Prepare(writer); err != nil {
+	bodyReader, contentLength, err := body.stream(boundary)
+	if err != nil {
 		return nil, err
 	}
 
-	_ = writer.Close()
-
-	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, body)
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bodyReader)
 	if err != nil {
+		_ = bodyReader.Close()
 		return nil, err
 	}
 
+	if contentLength >= 0 {
+		httpReq.ContentLength = contentLength
+	}
+	if body.reopenable() {
+		httpReq.GetBody = func() (io.ReadCloser, error) {
+			rc, _, err := body.stream(boundary)
+			return rc, err
+		}
+	}
+
 	if req.Proto != "" {
 		httpReq.Proto = req.Proto
 	}
@@ -89,7 +109,7 @@ func newMultipartFormDataRequest(ctx context.Context, req *Request) (*http.Reque
 	}
 
 	httpReq.Header.Set("Authorization", req.Auth)
-	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	httpReq.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
 	httpReq.Header.Set("User-Agent", defaultUserAgent)
 
 	return httpReq, nil
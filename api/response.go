@@ -26,6 +26,7 @@ type Response struct {
 	Header     http.Header `json:"-"` // 响应头部信息
 	RawBody    []byte      `json:"-"` // 原始响应正文
 	Rate       Rate        `json:"-"` // API 频率控制信息
+	Attempts   int         `json:"-"` // 请求尝试次数（包括首次请求），启用重试策略时可能大于 1
 }
 
 // 判断响应是否无正文内容。
@@ -0,0 +1,157 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// # 重试策略
+//
+// 用于配置 HttpClient 在遇到暂时性错误时的自动重试行为：
+//   - 每次重试前的等待时长采用带抖动的指数退避算法计算，即 BaseDelay * 2^(n-1)，且不超过 MaxDelay；
+//   - 网络错误和 5xx 服务端错误（500、502、503、504）仅对幂等请求重试，即 GET、HEAD、OPTIONS、DELETE 请求，以及被标记为 Request.Idempotent 的请求（如携带了 CID 的推送）；
+//   - 429 请求频率超限表示请求未被服务端受理，任何请求均可重试，等待时长优先遵循 `Retry-After` 响应头，其次是 `X-Rate-Limit-Reset` 响应头。
+type RetryPolicy struct {
+	MaxAttempts   int           // 最大尝试次数（包括首次请求），小于等于 1 表示不重试。
+	BaseDelay     time.Duration // 首次重试前的基础等待时长，为 0 时使用 200ms。
+	MaxDelay      time.Duration // 指数退避的单次最大等待时长，为 0 时使用 10s。
+	Jitter        float64       // 抖动系数，取值范围 [0, 1]，实际等待时长在 [(1-Jitter)*d, d] 之间随机，为 0 时表示不抖动。
+	MaxRetryAfter time.Duration // 遇到 429 时可接受的最长等待时长，服务端要求等待更久时直接返回该响应，为 0 时表示不限制。
+}
+
+const (
+	defaultRetryBaseDelay = 200 * time.Millisecond
+	defaultRetryMaxDelay  = 10 * time.Second
+)
+
+// 获取默认的重试策略：最多尝试 3 次，基础等待 200ms，最长等待 10s，抖动系数 0.5，429 时最多等待 60s。
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:   3,
+		BaseDelay:     defaultRetryBaseDelay,
+		MaxDelay:      defaultRetryMaxDelay,
+		Jitter:        0.5,
+		MaxRetryAfter: time.Minute,
+	}
+}
+
+// 根据第 attempt 次尝试的结果，判断是否需要重试，以及重试前需要等待的时长。
+func (p *RetryPolicy) next(attempt int, idempotent bool, resp *Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		return p.backoff(attempt), idempotent
+	}
+	if resp == nil {
+		return 0, false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		wait, ok := resp.retryAfter()
+		if !ok {
+			wait = p.backoff(attempt)
+		}
+		if p.MaxRetryAfter > 0 && wait > p.MaxRetryAfter {
+			return 0, false
+		}
+		return wait, true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !idempotent {
+			return 0, false
+		}
+		if wait, ok := resp.retryAfter(); ok && resp.StatusCode == http.StatusServiceUnavailable {
+			return wait, true
+		}
+		return p.backoff(attempt), true
+	default:
+		return 0, false
+	}
+}
+
+// 计算第 attempt 次尝试失败后的指数退避等待时长。
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	base, maxDelay := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	delay := maxDelay
+	if shift := attempt - 1; shift < 32 {
+		if d := base << shift; d > 0 && d < maxDelay {
+			delay = d
+		}
+	}
+
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+	return delay
+}
+
+// 从响应中解析服务端要求的重试等待时长，优先使用 `Retry-After` 响应头，其次是 `X-Rate-Limit-Reset` 响应头。
+func (resp *Response) retryAfter() (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(time.Until(t), 0), true
+		}
+	}
+	if resp.Rate.Reset > 0 {
+		return time.Duration(resp.Rate.Reset) * time.Second, true
+	}
+	return 0, false
+}
+
+// 判断请求是否为幂等请求，幂等请求在遇到网络错误或 5xx 服务端错误时可安全重试。
+func (req *Request) isIdempotent() bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	default:
+		return req.Idempotent
+	}
+}
+
+// 在等待指定时长后返回，如果上下文在此期间被取消，则提前返回上下文的错误。
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
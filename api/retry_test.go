@@ -0,0 +1,158 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// 按顺序返回给定状态码的测试服务器，并记录收到的请求次数和正文。
+func newStatusServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead { // 忽略协议版本探测请求
+			return
+		}
+		n := atomic.AddInt32(&calls, 1)
+		if body, _ := io.ReadAll(r.Body); r.Method == http.MethodPost && string(body) != `{"cid":"x"}` {
+			t.Errorf("attempt %d: unexpected body %q", n, body)
+		}
+		status := statuses[len(statuses)-1]
+		if int(n) <= len(statuses) {
+			status = statuses[n-1]
+		}
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := &api.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Jitter: 0.5}
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithRetryPolicy(policy))
+
+	tests := []struct {
+		name       string
+		method     string
+		idempotent bool
+		statuses   []int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{"get retries 5xx", http.MethodGet, false, []int{503, 502, 200}, 200, 3},
+		{"get gives up", http.MethodGet, false, []int{500}, 500, 3},
+		{"post without cid does not retry 5xx", http.MethodPost, false, []int{500, 200}, 500, 1},
+		{"post with cid retries 5xx", http.MethodPost, true, []int{500, 200}, 200, 2},
+		{"post retries 429", http.MethodPost, false, []int{429, 200}, 200, 2},
+		{"4xx is not retried", http.MethodDelete, false, []int{400, 200}, 400, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := newStatusServer(t, tt.statuses...)
+			req := &api.Request{Method: tt.method, URL: srv.URL, Body: map[string]string{"cid": "x"}, Idempotent: tt.idempotent}
+			resp, err := client.Request(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := atomic.LoadInt32(calls); got != tt.wantCalls || int32(resp.Attempts) != tt.wantCalls {
+				t.Errorf("calls = %d, attempts = %d, want %d", got, resp.Attempts, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyMaxRetryAfter(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead { // 忽略协议版本探测请求
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-Rate-Limit-Reset", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	policy := &api.RetryPolicy{MaxAttempts: 3, MaxRetryAfter: time.Second}
+	client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithRetryPolicy(policy))
+	_, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL})
+	if !errors.Is(err, api.ErrRateLimited) {
+		t.Errorf("err = %v, want ErrRateLimited", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestRetryPolicyTruncatedBody(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead { // 忽略协议版本探测请求
+			return
+		}
+		if atomic.AddInt32(&calls, 1) > 1 {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		// 声明了完整的正文长度，但只发送一部分后就断开连接。
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: 100\r\n\r\n{\"cid\":")
+		_ = buf.Flush()
+		_ = conn.Close()
+	}))
+	defer srv.Close()
+
+	t.Run("without retry policy", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone)
+		resp, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL})
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("resp = %v, err = %v, want io.ErrUnexpectedEOF", resp, err)
+		}
+	})
+
+	t.Run("with retry policy", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		policy := &api.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+		client := api.NewHttpClient(nil, nil, api.HttpLogLevelNone, api.WithRetryPolicy(policy))
+		resp, err := client.Request(context.Background(), &api.Request{Method: http.MethodGet, URL: srv.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK || resp.Attempts != 2 {
+			t.Errorf("status = %d, attempts = %d, want 200 after 2 attempts", resp.StatusCode, resp.Attempts)
+		}
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
 	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
 	"github.com/cavlabs/jiguang-sdk-go/examples/adapter"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest/cassette"
 	"github.com/hashicorp/go-retryablehttp"
 )
 
@@ -71,7 +72,22 @@ func TestMain(m *testing.M) {
 		},
 		ForceAttemptHTTP2: true,
 	}*/
-	client := retryClient.StandardClient() // *http.Client
+	var client api.Client = retryClient.StandardClient() // *http.Client
+
+	// 设置环境变量 JIGUANG_CASSETTE=record 时，将与极光服务的真实交互录制到 testdata/cassette.json；
+	// 设置为 JIGUANG_CASSETTE=replay 时，从中回放录制的响应，以便在 CI 中离线运行这些示例。
+	var rec *cassette.Cassette
+	if env := os.Getenv("JIGUANG_CASSETTE"); env != "" {
+		mode, err := cassette.ParseMode(env)
+		if err != nil {
+			panic(err)
+		}
+		rec, err = cassette.New("testdata/cassette.json", mode, cassette.WithClient(client), cassette.WithRedactedFields("mobile"))
+		if err != nil {
+			panic(err)
+		}
+		client = rec
+	}
 
 	// ###################### ↓↓↓ 此为演示数据，请替换成真实数据 ↓↓↓ ######################
 
@@ -177,5 +193,11 @@ func TestMain(m *testing.M) {
 
 	code := m.Run()
 
+	if rec != nil {
+		if err := rec.Save(); err != nil {
+			panic(err)
+		}
+	}
+
 	os.Exit(code)
 }
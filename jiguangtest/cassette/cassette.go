@@ -0,0 +1,382 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cassette 提供了录制/回放 HTTP 交互的 api.Client 实现，用于编写确定性的集成测试。
+//
+// 录制模式下，请求被转发给真实的 api.Client，请求和响应被记录下来，并在调用 Save 时写入 JSON 格式的夹具文件；
+// 回放模式下，不发起任何网络请求，而是按照请求方法、URL 和规范化后的 JSON 正文从夹具文件中查找并返回录制的响应。
+//
+// `Authorization` 请求头总是会被脱敏，还可以通过 WithRedactedFields 对 JSON 正文及查询参数中的敏感字段（如手机号码）脱敏，
+// 脱敏在录制和匹配时都会进行，因此回放时使用不同的凭证或手机号码也能匹配到录制的交互：
+//
+//	c, err := cassette.New("testdata/push.json", cassette.Record, cassette.WithRedactedFields("mobile"))
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer c.Save()
+//
+//	pushAPIv3, _ := push.NewAPIv3Builder().SetClient(c).SetAppKey(appKey).SetMasterSecret(masterSecret).Build()
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+)
+
+// Mode 是录制/回放模式。
+type Mode int
+
+const (
+	Replay Mode = iota // 回放模式，从夹具文件中返回录制的响应，不发起网络请求。
+	Record             // 录制模式，转发请求给真实的 api.Client 并记录请求和响应。
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Replay:
+		return "replay"
+	case Record:
+		return "record"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// 解析录制/回放模式，s 可以是 "replay" 或 "record"（不区分大小写），通常来自环境变量。
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "replay":
+		return Replay, nil
+	case "record":
+		return Record, nil
+	default:
+		return Replay, fmt.Errorf("invalid cassette mode %q", s)
+	}
+}
+
+// 脱敏后的值。
+const Redacted = "[REDACTED]"
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Interaction 是一次录制的 HTTP 交互。
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request 是录制的请求，URL 和正文均已脱敏，JSON 正文已规范化（键按字典序排列）；多部分表单的正文不会被录制。
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response 是录制的响应，正文已脱敏。
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Proto      string      `json:"proto,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// 夹具文件的内容。
+type fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Cassette 是录制/回放 HTTP 交互的 api.Client，可被多个 goroutine 并发使用。
+type Cassette struct {
+	path    string
+	mode    Mode
+	client  api.Client
+	headers map[string]bool // 需要脱敏的请求头和响应头（规范化的名称）
+	fields  map[string]bool // 需要脱敏的 JSON 字段和查询参数
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// 录制/回放的配置选项。
+type Option func(*Cassette)
+
+// 设置录制模式下实际发送请求的客户端，默认为 api.DefaultClient。
+func WithClient(client api.Client) Option {
+	return func(c *Cassette) {
+		c.client = client
+	}
+}
+
+// 设置额外需要脱敏的请求头和响应头，`Authorization` 请求头总是会被脱敏。
+func WithRedactedHeaders(headers ...string) Option {
+	return func(c *Cassette) {
+		for _, h := range headers {
+			c.headers[http.CanonicalHeaderKey(h)] = true
+		}
+	}
+}
+
+// 设置需要脱敏的字段名称，JSON 正文（包括嵌套的对象）中的同名字段及同名查询参数的值都会被替换为 Redacted。
+func WithRedactedFields(fields ...string) Option {
+	return func(c *Cassette) {
+		for _, f := range fields {
+			c.fields[f] = true
+		}
+	}
+}
+
+// 创建录制/回放的客户端。
+//   - 回放模式下，夹具文件 path 必须存在；
+//   - 录制模式下，录制的交互只有在调用 Save 后才会写入 path，已存在的文件会被覆盖。
+func New(path string, mode Mode, opts ...Option) (*Cassette, error) {
+	c := &Cassette{
+		path:    path,
+		mode:    mode,
+		client:  api.DefaultClient,
+		headers: map[string]bool{"Authorization": true},
+		fields:  make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if mode == Replay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var f fixture
+		if err = json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parse cassette %q: %w", path, err)
+		}
+		c.interactions = f.Interactions
+		c.used = make([]bool, len(f.Interactions))
+	}
+	return c, nil
+}
+
+// 获取录制/回放模式。
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// 获取已录制（或已加载）的全部交互。
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := make([]Interaction, len(c.interactions))
+	for i, it := range c.interactions {
+		result[i] = *it
+	}
+	return result
+}
+
+// 将录制的交互写入夹具文件，必要时创建其所在的目录；回放模式下不做任何操作。
+func (c *Cassette) Save() error {
+	if c.mode != Record {
+		return nil
+	}
+	c.mu.Lock()
+	data, err := json.MarshalIndent(fixture{Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 实现 api.Client 接口。
+//
+// HTTP 协议版本探测的 HEAD 请求不会被录制：录制模式下直接转发，回放模式下总是返回 "HTTP/1.1" 的空响应。
+func (c *Cassette) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodHead {
+		if c.mode == Record {
+			return c.client.Do(req)
+		}
+		return newResponse(req, Response{StatusCode: http.StatusOK, Proto: "HTTP/1.1"}), nil
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	recorded := Request{
+		Method: req.Method,
+		URL:    c.redactURL(req.URL),
+		Header: c.redactHeader(req.Header),
+		Body:   c.normalizeBody(req.Header.Get("Content-Type"), body),
+	}
+
+	if c.mode == Record {
+		return c.record(req, body, recorded)
+	}
+	return c.replay(req, recorded)
+}
+
+func (c *Cassette) record(req *http.Request, body []byte, recorded Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, &Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Proto:      resp.Proto,
+			Header:     c.redactHeader(resp.Header),
+			Body:       c.normalizeBody(resp.Header.Get("Content-Type"), respBody),
+		},
+	})
+	c.mu.Unlock()
+	return resp, nil
+}
+
+// 按照录制的顺序查找第一个尚未被使用的匹配交互。
+func (c *Cassette) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, it := range c.interactions {
+		if c.used[i] {
+			continue
+		}
+		if it.Request.Method == recorded.Method && it.Request.URL == recorded.URL && it.Request.Body == recorded.Body {
+			c.used[i] = true
+			return newResponse(req, it.Response), nil
+		}
+	}
+	return nil, fmt.Errorf("cassette %q: no recorded interaction for %s %s", c.path, recorded.Method, recorded.URL)
+}
+
+func newResponse(req *http.Request, r Response) *http.Response {
+	major, minor, ok := http.ParseHTTPVersion(r.Proto)
+	if !ok {
+		r.Proto, major, minor = "HTTP/1.1", 1, 1
+	}
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         r.Proto,
+		ProtoMajor:    major,
+		ProtoMinor:    minor,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (c *Cassette) redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	result := h.Clone()
+	for k := range result {
+		if c.headers[http.CanonicalHeaderKey(k)] {
+			result[k] = []string{Redacted}
+		}
+	}
+	return result
+}
+
+func (c *Cassette) redactURL(u *url.URL) string {
+	if len(c.fields) == 0 || u.RawQuery == "" {
+		return u.String()
+	}
+	query := u.Query()
+	for k := range query {
+		if c.fields[k] {
+			query[k] = []string{Redacted}
+		}
+	}
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// 规范化正文：JSON 正文在脱敏后重新序列化（键按字典序排列），多部分表单的正文被忽略（其分隔符是随机生成的），其他正文保持不变。
+func (c *Cassette) normalizeBody(contentType string, body []byte) string {
+	if strings.HasPrefix(contentType, "multipart/") {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return string(body)
+	}
+	data, err := json.Marshal(c.redact(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+func (c *Cassette) redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range v {
+			if c.fields[k] {
+				v[k] = Redacted
+			} else {
+				v[k] = c.redact(vv)
+			}
+		}
+	case []interface{}:
+		for i, vv := range v {
+			v[i] = c.redact(vv)
+		}
+	}
+	return v
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassette_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jsms"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest/cassette"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	ctx := context.Background()
+	param := &push.SendParam{
+		Platform:     platform.All,
+		Audience:     push.BroadcastAuds,
+		Notification: &push.Notification{Alert: "Hello, JPush!"},
+	}
+
+	// 录制
+	srv := jiguangtest.NewServer()
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android})
+	rec, err := cassette.New(path, cassette.Record, cassette.WithRedactedFields("mobile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := srv.Config()
+	cfg.Client = rec
+	client := sdk.NewClient(cfg)
+
+	pushAPIv3, _ := client.Push()
+	recorded, err := pushAPIv3.Send(ctx, param)
+	if err != nil || !recorded.IsSuccess() {
+		t.Fatalf("Send() = %+v, %v", recorded, err)
+	}
+	smsAPIv1, _ := client.SMS()
+	if _, err = smsAPIv1.SendCode(ctx, &jsms.CodeSendParam{Mobile: "13800138000", TempID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err = rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{jiguangtest.DefaultMasterSecret, "Basic ", "13800138000"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	// 回放，服务已关闭，使用不同的凭证和手机号码
+	play, err := cassette.New(path, cassette.Replay, cassette.WithRedactedFields("mobile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(play.Interactions()); n != 2 {
+		t.Fatalf("len(Interactions()) = %d, want 2", n)
+	}
+	cfg.Client = play
+	cfg.MasterSecret = "another-secret"
+	client = sdk.NewClient(cfg)
+
+	pushAPIv3, _ = client.Push()
+	replayed, err := pushAPIv3.Send(ctx, param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.MsgID != recorded.MsgID {
+		t.Errorf("replayed msg_id = %q, want %q", replayed.MsgID, recorded.MsgID)
+	}
+	smsAPIv1, _ = client.SMS()
+	if sent, err := smsAPIv1.SendCode(ctx, &jsms.CodeSendParam{Mobile: "13900139000", TempID: 1}); err != nil || !sent.IsSuccess() {
+		t.Fatalf("SendCode() = %+v, %v", sent, err)
+	}
+
+	// 每个录制的交互只能被回放一次
+	if _, err = pushAPIv3.Send(ctx, param); err == nil {
+		t.Error("Send() without remaining recorded interaction should fail")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	if m, err := cassette.ParseMode("RECORD"); err != nil || m != cassette.Record {
+		t.Errorf("ParseMode(RECORD) = %v, %v", m, err)
+	}
+	if _, err := cassette.ParseMode("live"); err == nil {
+		t.Error("ParseMode(live) should fail")
+	}
+}
@@ -0,0 +1,769 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiguangtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+)
+
+// JPush 模拟服务使用的错误码。
+const (
+	codeInvalidParam    = 1003 // 参数值不合法
+	codeNoTargets       = 1011 // 没有满足条件的推送目标
+	codeIllegalDevice   = 7002 // 设备标识不合法或不存在
+	codeScheduleMissing = 8505 // 定时任务不存在
+)
+
+const schedulesPageSize = 50 // 定时任务列表每页的数量
+
+func (s *Server) routeJPush(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v3/push", s.handlePush(false))
+	mux.HandleFunc("POST /v3/push/validate", s.handlePush(true))
+	mux.HandleFunc("POST /v3/push/batch/{type}/single", s.handleBatchPush)
+	mux.HandleFunc("GET /v3/push/cid", s.handleGetCid)
+
+	mux.HandleFunc("GET /v3/devices/{rid}", s.handleGetDevice)
+	mux.HandleFunc("POST /v3/devices/{rid}", s.handleSetDevice)
+	mux.HandleFunc("GET /v3/tags", s.handleGetTags)
+	mux.HandleFunc("GET /v3/tags/{tag}/registration_ids/{rid}", s.handleGetTag)
+	mux.HandleFunc("POST /v3/tags/{tag}", s.handleSetTag)
+	mux.HandleFunc("DELETE /v3/tags/{tag}", s.handleDeleteTag)
+	mux.HandleFunc("GET /v3/aliases/{alias}", s.handleGetAlias)
+	mux.HandleFunc("POST /v3/aliases/{alias}", s.handleDeleteAliases)
+	mux.HandleFunc("DELETE /v3/aliases/{alias}", s.handleDeleteAlias)
+
+	mux.HandleFunc("POST /v3/schedules", s.handleCreateSchedule)
+	mux.HandleFunc("GET /v3/schedules", s.handleGetSchedules)
+	mux.HandleFunc("GET /v3/schedules/{id}", s.handleGetSchedule)
+	mux.HandleFunc("PUT /v3/schedules/{id}", s.handleUpdateSchedule)
+	mux.HandleFunc("DELETE /v3/schedules/{id}", s.handleDeleteSchedule)
+	mux.HandleFunc("GET /v3/schedules/{id}/msg_ids", s.handleGetScheduleMsgIDs)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Device 是模拟服务中的一个设备。
+type Device struct {
+	RegistrationID string            // 设备标识 Registration ID
+	Platform       platform.Platform // 设备平台
+	Alias          string            // 设备别名
+	Tags           []string          // 设备标签
+	Mobile         string            // 设备手机号码
+}
+
+func (d *Device) hasTag(tag string) bool {
+	for _, t := range d.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Device) addTag(tag string) {
+	if !d.hasTag(tag) {
+		d.Tags = append(d.Tags, tag)
+		sort.Strings(d.Tags)
+	}
+}
+
+func (d *Device) removeTag(tag string) {
+	for i, t := range d.Tags {
+		if t == tag {
+			d.Tags = append(d.Tags[:i], d.Tags[i+1:]...)
+			return
+		}
+	}
+}
+
+func (d *Device) clone() Device {
+	c := *d
+	c.Tags = append([]string(nil), d.Tags...)
+	return c
+}
+
+// 添加或替换设备，推送时只有匹配到已添加的设备才会成功，否则返回错误码 1011。
+func (s *Server) AddDevice(d Device) {
+	d.Tags = append([]string(nil), d.Tags...)
+	sort.Strings(d.Tags)
+	s.mu.Lock()
+	s.devices[d.RegistrationID] = &d
+	s.mu.Unlock()
+}
+
+// 获取设备的当前状态。
+func (s *Server) Device(registrationID string) (Device, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.devices[registrationID]
+	if !ok {
+		return Device{}, false
+	}
+	return d.clone(), true
+}
+
+// 按 Registration ID 排序的所有设备，调用前需要持有锁。
+func (s *Server) sortedDevices() []*Device {
+	devices := make([]*Device, 0, len(s.devices))
+	for _, d := range s.devices {
+		devices = append(devices, d)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].RegistrationID < devices[j].RegistrationID })
+	return devices
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Push 是模拟服务中一条成功的推送的记录。
+type Push struct {
+	MsgID   string          // 推送消息 ID
+	CID     string          // 推送唯一标识，未设置时为空
+	Targets []string        // 命中的设备 Registration ID 列表，按字典序排列
+	Payload json.RawMessage // 推送请求正文，对于批量单推，为单个 CID 对应的推送参数
+}
+
+// 获取所有成功的推送（不包括推送校验），按推送的顺序排列。
+func (s *Server) Pushes() []Push {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Push(nil), s.pushes...)
+}
+
+// 推送参数中模拟服务关心的部分。
+type pushParam struct {
+	CID      string          `json:"cid"`
+	Platform json.RawMessage `json:"platform"`
+	Audience json.RawMessage `json:"audience"`
+	Target   string          `json:"target"` // 批量单推
+	Options  *struct {
+		SendNo int `json:"sendno"`
+	} `json:"options"`
+	Notification    json.RawMessage `json:"notification"`
+	Message         json.RawMessage `json:"message"`
+	LiveActivity    json.RawMessage `json:"live_activity"`
+	InAppMessage    json.RawMessage `json:"inapp_message"`
+	Notification3rd json.RawMessage `json:"notification_3rd"`
+	VoIP            json.RawMessage `json:"voip"`
+}
+
+// 检查推送内容，返回错误码和错误信息。
+func (p *pushParam) validate() (int, string) {
+	if len(p.Platform) == 0 {
+		return codeInvalidParam, "platform is required"
+	}
+	if len(p.Notification) == 0 && len(p.Message) == 0 && len(p.LiveActivity) == 0 &&
+		len(p.InAppMessage) == 0 && len(p.Notification3rd) == 0 && len(p.VoIP) == 0 {
+		return codeInvalidParam, "notification or message is required"
+	}
+	return 0, ""
+}
+
+func (p *pushParam) sendNo() string {
+	if p.Options == nil {
+		return "0"
+	}
+	return strconv.Itoa(p.Options.SendNo)
+}
+
+// 解析推送平台，返回 nil 表示所有平台。
+func parsePlatforms(raw json.RawMessage) (map[platform.Platform]bool, bool) {
+	var all string
+	if json.Unmarshal(raw, &all) == nil {
+		return nil, all == "all"
+	}
+	var plats []platform.Platform
+	if err := json.Unmarshal(raw, &plats); err != nil || len(plats) == 0 {
+		return nil, false
+	}
+	set := make(map[platform.Platform]bool, len(plats))
+	for _, p := range plats {
+		set[p] = true
+	}
+	return set, true
+}
+
+// 根据推送平台和推送目标匹配设备，调用前需要持有锁。
+//   - 不同类型的推送目标之间取交集，同一类型的多个值之间取并集（tag_and 取交集，tag_not 取补集）；
+//   - 不支持模拟的推送目标类型（如 segment、abtest、file 等）不参与匹配。
+func (s *Server) resolveTargets(platformRaw, audienceRaw json.RawMessage) ([]string, int, string) {
+	plats, ok := parsePlatforms(platformRaw)
+	if !ok {
+		return nil, codeInvalidParam, "invalid platform"
+	}
+
+	var all string
+	audience := map[string][]string{}
+	if json.Unmarshal(audienceRaw, &all) == nil {
+		if all != "all" {
+			return nil, codeInvalidParam, "invalid audience"
+		}
+	} else {
+		var aux map[string]json.RawMessage
+		if err := json.Unmarshal(audienceRaw, &aux); err != nil {
+			return nil, codeInvalidParam, "audience value must be JSON Array format!"
+		}
+		for _, key := range []string{"registration_id", "alias", "tag", "tag_and", "tag_not"} {
+			if raw, ok := aux[key]; ok {
+				var values []string
+				if err := json.Unmarshal(raw, &values); err != nil {
+					return nil, codeInvalidParam, "audience value must be JSON Array format!"
+				}
+				audience[key] = values
+			}
+		}
+	}
+
+	var targets []string
+	for _, d := range s.sortedDevices() {
+		if plats != nil && !plats[d.Platform] {
+			continue
+		}
+		if matchAudience(d, audience) {
+			targets = append(targets, d.RegistrationID)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, codeNoTargets, "cannot find user by this audience or has been inactive for more than 255 days"
+	}
+	return targets, 0, ""
+}
+
+func matchAudience(d *Device, audience map[string][]string) bool {
+	contains := func(values []string, v string) bool {
+		for _, value := range values {
+			if value == v {
+				return true
+			}
+		}
+		return false
+	}
+	if rids, ok := audience["registration_id"]; ok && !contains(rids, d.RegistrationID) {
+		return false
+	}
+	if aliases, ok := audience["alias"]; ok && (d.Alias == "" || !contains(aliases, d.Alias)) {
+		return false
+	}
+	if tags, ok := audience["tag"]; ok {
+		matched := false
+		for _, tag := range tags {
+			matched = matched || d.hasTag(tag)
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, tag := range audience["tag_and"] {
+		if !d.hasTag(tag) {
+			return false
+		}
+	}
+	for _, tag := range audience["tag_not"] {
+		if d.hasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// 生成推送消息 ID，调用前需要持有锁。
+func (s *Server) newMsgID() string {
+	s.seq++
+	return strconv.FormatInt(1000000000+s.seq, 10)
+}
+
+// 「推送」和「推送校验」，带有 CID 的推送会被去重，即再次使用相同的 CID 推送时直接返回第一次推送的结果。
+func (s *Server) handlePush(validate bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var param pushParam
+		if !decodeBody(w, r, &param) {
+			return
+		}
+		if code, msg := param.validate(); code != 0 {
+			writeError(w, r, http.StatusBadRequest, code, msg)
+			return
+		}
+		if len(param.Audience) == 0 {
+			writeError(w, r, http.StatusBadRequest, codeInvalidParam, "audience is required")
+			return
+		}
+
+		s.mu.Lock()
+		if msgID, ok := s.pushesByCID[param.CID]; ok && !validate {
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, map[string]string{"sendno": param.sendNo(), "msg_id": msgID})
+			return
+		}
+		targets, code, msg := s.resolveTargets(param.Platform, param.Audience)
+		if code != 0 {
+			s.mu.Unlock()
+			writeError(w, r, http.StatusBadRequest, code, msg)
+			return
+		}
+		msgID := s.newMsgID()
+		if !validate {
+			s.pushes = append(s.pushes, Push{MsgID: msgID, CID: param.CID, Targets: targets, Payload: readBody(r)})
+			if param.CID != "" {
+				s.pushesByCID[param.CID] = msgID
+			}
+		}
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, map[string]string{"sendno": param.sendNo(), "msg_id": msgID})
+	}
+}
+
+// 「批量单推」，每个 CID 对应的推送单独返回结果。
+func (s *Server) handleBatchPush(w http.ResponseWriter, r *http.Request) {
+	byType := r.PathValue("type")
+	if byType != "regid" && byType != "alias" {
+		writeError(w, r, http.StatusNotFound, codeInvalidParam, "unsupported batch type: "+byType)
+		return
+	}
+	var body struct {
+		PushList map[string]json.RawMessage `json:"pushlist"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.PushList) == 0 || len(body.PushList) > 1000 {
+		writeError(w, r, http.StatusBadRequest, codeInvalidParam, "pushlist size must be between 1 and 1000")
+		return
+	}
+
+	cids := make([]string, 0, len(body.PushList))
+	for cid := range body.PushList {
+		cids = append(cids, cid)
+	}
+	sort.Strings(cids)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make(map[string]interface{}, len(cids))
+	for _, cid := range cids {
+		raw := body.PushList[cid]
+		var param pushParam
+		if err := json.Unmarshal(raw, &param); err != nil {
+			results[cid] = map[string]interface{}{"error": map[string]interface{}{"code": codeInvalidParam, "message": err.Error()}}
+			continue
+		}
+		if code, msg := param.validate(); code != 0 {
+			results[cid] = map[string]interface{}{"error": map[string]interface{}{"code": code, "message": msg}}
+			continue
+		}
+		if msgID, ok := s.pushesByCID[cid]; ok {
+			results[cid] = map[string]string{"msg_id": msgID}
+			continue
+		}
+		key := "registration_id"
+		if byType == "alias" {
+			key = "alias"
+		}
+		audience, _ := json.Marshal(map[string][]string{key: {param.Target}})
+		targets, code, msg := s.resolveTargets(param.Platform, audience)
+		if code != 0 {
+			results[cid] = map[string]interface{}{"error": map[string]interface{}{"code": code, "message": msg}}
+			continue
+		}
+		msgID := s.newMsgID()
+		s.pushes = append(s.pushes, Push{MsgID: msgID, CID: cid, Targets: targets, Payload: raw})
+		s.pushesByCID[cid] = msgID
+		results[cid] = map[string]string{"msg_id": msgID}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// 「获取推送唯一标识 (CID)」，格式为 {appkey}-{uuid}。
+func (s *Server) handleGetCid(w http.ResponseWriter, r *http.Request) {
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	if count < 1 {
+		count = 1
+	}
+	if count > 1000 {
+		writeError(w, r, http.StatusBadRequest, codeInvalidParam, "count cannot be more than 1000")
+		return
+	}
+	appKey, _, _ := basicAuth(r)
+	cids := make([]string, count)
+	for i := range cids {
+		cids[i] = fmt.Sprintf("%s-00000000-0000-0000-0000-%012d", appKey, s.nextID())
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{"cidlist": cids})
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 获取请求路径中的设备，不存在时写入错误响应，调用前需要持有锁。
+func (s *Server) lookupDevice(w http.ResponseWriter, r *http.Request) (*Device, bool) {
+	rid := r.PathValue("rid")
+	d, ok := s.devices[rid]
+	if !ok {
+		writeError(w, r, http.StatusBadRequest, codeIllegalDevice, "illegal registration_id: "+rid)
+	}
+	return d, ok
+}
+
+// 「查询设备的别名与标签」
+func (s *Server) handleGetDevice(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.lookupDevice(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tags": d.Tags, "alias": d.Alias, "mobile": d.Mobile})
+}
+
+// 「设置设备的别名与标签」
+func (s *Server) handleSetDevice(w http.ResponseWriter, r *http.Request) {
+	var param struct {
+		Tags   json.RawMessage `json:"tags"`
+		Alias  *string         `json:"alias"`
+		Mobile *string         `json:"mobile"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.lookupDevice(w, r)
+	if !ok {
+		return
+	}
+	if len(param.Tags) > 0 {
+		var clear string
+		var tags struct {
+			Add    []string `json:"add"`
+			Remove []string `json:"remove"`
+		}
+		if json.Unmarshal(param.Tags, &clear) == nil {
+			d.Tags = nil // 空字符串表示清空所有标签
+		} else if err := json.Unmarshal(param.Tags, &tags); err == nil {
+			for _, tag := range tags.Add {
+				d.addTag(tag)
+			}
+			for _, tag := range tags.Remove {
+				d.removeTag(tag)
+			}
+		} else {
+			writeError(w, r, http.StatusBadRequest, codeIllegalDevice, "invalid tags")
+			return
+		}
+	}
+	if param.Alias != nil {
+		d.Alias = *param.Alias
+	}
+	if param.Mobile != nil {
+		d.Mobile = *param.Mobile
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// 「查询标签列表」
+func (s *Server) handleGetTags(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	set := make(map[string]bool)
+	for _, d := range s.devices {
+		for _, tag := range d.Tags {
+			set[tag] = true
+		}
+	}
+	s.mu.Unlock()
+
+	tags := make([]string, 0, len(set))
+	for tag := range set {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	writeJSON(w, http.StatusOK, map[string][]string{"tags": tags})
+}
+
+// 「判断设备与标签绑定关系」
+func (s *Server) handleGetTag(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.lookupDevice(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"result": d.hasTag(r.PathValue("tag"))})
+}
+
+// 「更新标签」，包含不存在的设备时返回非法的 Registration ID 列表。
+func (s *Server) handleSetTag(w http.ResponseWriter, r *http.Request) {
+	var param struct {
+		RegistrationIDs struct {
+			Add    []string `json:"add"`
+			Remove []string `json:"remove"`
+		} `json:"registration_ids"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	tag := r.PathValue("tag")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var illegal []string
+	for _, rid := range append(append([]string(nil), param.RegistrationIDs.Add...), param.RegistrationIDs.Remove...) {
+		if _, ok := s.devices[rid]; !ok {
+			illegal = append(illegal, rid)
+		}
+	}
+	if len(illegal) > 0 {
+		writeErrorWith(w, r, http.StatusBadRequest, codeIllegalDevice, "illegal registration_ids", map[string]interface{}{"illegal_rids": illegal})
+		return
+	}
+	for _, rid := range param.RegistrationIDs.Add {
+		s.devices[rid].addTag(tag)
+	}
+	for _, rid := range param.RegistrationIDs.Remove {
+		s.devices[rid].removeTag(tag)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// 解析 `platform` 查询参数，为空时表示所有平台。
+func platformFilter(r *http.Request) func(d *Device) bool {
+	query := r.URL.Query().Get("platform")
+	if query == "" {
+		return func(*Device) bool { return true }
+	}
+	plats := make(map[platform.Platform]bool)
+	for _, p := range strings.Split(query, ",") {
+		plats[platform.Platform(p)] = true
+	}
+	return func(d *Device) bool { return plats[d.Platform] }
+}
+
+// 「删除标签」
+func (s *Server) handleDeleteTag(w http.ResponseWriter, r *http.Request) {
+	tag, match := r.PathValue("tag"), platformFilter(r)
+	s.mu.Lock()
+	for _, d := range s.devices {
+		if match(d) {
+			d.removeTag(tag)
+		}
+	}
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+// 「查询别名」，支持 `new_format=true` 的新格式。
+func (s *Server) handleGetAlias(w http.ResponseWriter, r *http.Request) {
+	alias, match := r.PathValue("alias"), platformFilter(r)
+	type aliasData struct {
+		RegistrationID string            `json:"registration_id"`
+		Platform       platform.Platform `json:"platform"`
+	}
+	var (
+		rids []string
+		data []aliasData
+	)
+	s.mu.Lock()
+	for _, d := range s.sortedDevices() {
+		if d.Alias == alias && match(d) {
+			rids = append(rids, d.RegistrationID)
+			data = append(data, aliasData{RegistrationID: d.RegistrationID, Platform: d.Platform})
+		}
+	}
+	s.mu.Unlock()
+
+	if r.URL.Query().Get("new_format") == "true" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"registration_ids": rids})
+}
+
+// 「删除别名」
+func (s *Server) handleDeleteAlias(w http.ResponseWriter, r *http.Request) {
+	alias, match := r.PathValue("alias"), platformFilter(r)
+	s.mu.Lock()
+	for _, d := range s.devices {
+		if d.Alias == alias && match(d) {
+			d.Alias = ""
+		}
+	}
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+// 「解绑设备与别名的绑定关系」
+func (s *Server) handleDeleteAliases(w http.ResponseWriter, r *http.Request) {
+	var param struct {
+		RegistrationIDs struct {
+			Remove []string `json:"remove"`
+		} `json:"registration_ids"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+	alias := r.PathValue("alias")
+	s.mu.Lock()
+	for _, rid := range param.RegistrationIDs.Remove {
+		if d, ok := s.devices[rid]; ok && d.Alias == alias {
+			d.Alias = ""
+		}
+	}
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 获取所有定时任务的当前状态，按创建的顺序排列，每个定时任务均包含 `schedule_id` 字段。
+func (s *Server) Schedules() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]map[string]interface{}, 0, len(s.scheduleIDs))
+	for _, id := range s.scheduleIDs {
+		result = append(result, copySchedule(s.schedules[id]))
+	}
+	return result
+}
+
+func copySchedule(schedule map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(schedule))
+	for k, v := range schedule {
+		c[k] = v
+	}
+	return c
+}
+
+// 「创建定时任务」
+func (s *Server) handleCreateSchedule(w http.ResponseWriter, r *http.Request) {
+	var schedule map[string]interface{}
+	if !decodeBody(w, r, &schedule) {
+		return
+	}
+	name, _ := schedule["name"].(string)
+	if name == "" || schedule["trigger"] == nil || schedule["push"] == nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidParam, "name, trigger and push are required")
+		return
+	}
+
+	s.mu.Lock()
+	s.seq++
+	id := fmt.Sprintf("00000000-0000-0000-0000-%012d", s.seq)
+	schedule["schedule_id"] = id
+	s.schedules[id] = schedule
+	s.scheduleIDs = append(s.scheduleIDs, id)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]string{"schedule_id": id, "name": name})
+}
+
+// 「获取有效的定时任务列表」
+func (s *Server) handleGetSchedules(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	all := s.Schedules()
+	totalPages := (len(all) + schedulesPageSize - 1) / schedulesPageSize
+	start, end := (page-1)*schedulesPageSize, page*schedulesPageSize
+	if start > len(all) {
+		start = len(all)
+	}
+	if end > len(all) {
+		end = len(all)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total_count": len(all),
+		"total_pages": totalPages,
+		"page":        page,
+		"schedules":   all[start:end],
+	})
+}
+
+// 获取请求路径中的定时任务，不存在时写入错误响应，调用前需要持有锁。
+func (s *Server) lookupSchedule(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	schedule, ok := s.schedules[r.PathValue("id")]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, codeScheduleMissing, "schedule not exist")
+	}
+	return schedule, ok
+}
+
+// 「获取定时任务详情」
+func (s *Server) handleGetSchedule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	schedule, ok := s.lookupSchedule(w, r)
+	if ok {
+		schedule = copySchedule(schedule)
+	}
+	s.mu.Unlock()
+	if ok {
+		writeJSON(w, http.StatusOK, schedule)
+	}
+}
+
+// 「更新定时任务」，只更新请求中给出的字段。
+func (s *Server) handleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	var update map[string]interface{}
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	s.mu.Lock()
+	schedule, ok := s.lookupSchedule(w, r)
+	if ok {
+		for k, v := range update {
+			if k != "schedule_id" {
+				schedule[k] = v
+			}
+		}
+		schedule = copySchedule(schedule)
+	}
+	s.mu.Unlock()
+	if ok {
+		writeJSON(w, http.StatusOK, schedule)
+	}
+}
+
+// 「删除定时任务」
+func (s *Server) handleDeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.lookupSchedule(w, r); !ok {
+		return
+	}
+	id := r.PathValue("id")
+	delete(s.schedules, id)
+	for i, sid := range s.scheduleIDs {
+		if sid == id {
+			s.scheduleIDs = append(s.scheduleIDs[:i], s.scheduleIDs[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// 「获取定时任务对应的所有消息 ID」，模拟服务不会执行定时任务，因此总是为空。
+func (s *Server) handleGetScheduleMsgIDs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	_, ok := s.lookupSchedule(w, r)
+	s.mu.Unlock()
+	if ok {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"count": 0, "msgids": []string{}})
+	}
+}
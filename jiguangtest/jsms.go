@@ -0,0 +1,154 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiguangtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// JSMS 模拟服务使用的错误码。
+const (
+	codeSmsInvalidParam  = 50000 // 请求参数不合法
+	codeSmsCodeInvalid   = 50010 // 验证码无效
+	codeSmsCodeVerified  = 50012 // 验证码已验证通过
+	codeSmsMsgIDNotFound = 50011 // 验证码过期或消息 ID 不存在
+)
+
+func (s *Server) routeJSms(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/codes", s.handleSendCode)
+	mux.HandleFunc("POST /v1/codes/{msg_id}/valid", s.handleVerifyCode)
+	mux.HandleFunc("POST /v1/messages", s.handleSendMessage)
+}
+
+// SMSMessage 是模拟服务中一条发送成功的短信的记录。
+type SMSMessage struct {
+	MsgID      string                 // 短信消息 ID
+	Mobile     string                 // 手机号码
+	TempID     int64                  // 模板 ID
+	TempParams map[string]interface{} // 模板参数，对于验证码短信为空
+	Code       string                 // 验证码，对于模板短信为空
+}
+
+type smsCode struct {
+	code     string
+	verified bool
+}
+
+// 获取所有发送成功的短信（包括验证码短信和模板短信），按发送的顺序排列。
+func (s *Server) SMSMessages() []SMSMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SMSMessage(nil), s.smsMessages...)
+}
+
+// 获取验证码短信的验证码，msgID 不存在时返回空字符串。
+func (s *Server) SMSCode(msgID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.smsCodes[msgID]; ok {
+		return c.code
+	}
+	return ""
+}
+
+type smsParam struct {
+	Mobile     string                 `json:"mobile"`
+	TempID     int64                  `json:"temp_id"`
+	TempParams map[string]interface{} `json:"temp_para"`
+}
+
+// 解析短信发送参数，失败时写入错误响应并返回 false。
+func decodeSmsParam(w http.ResponseWriter, r *http.Request) (smsParam, bool) {
+	var param smsParam
+	if !decodeBody(w, r, &param) {
+		return param, false
+	}
+	if param.Mobile == "" || param.TempID == 0 {
+		writeError(w, r, http.StatusBadRequest, codeSmsInvalidParam, "mobile and temp_id are required")
+		return param, false
+	}
+	return param, true
+}
+
+// 「发送文本验证码短信」，验证码由 WithCodeProvider 设置的函数生成。
+func (s *Server) handleSendCode(w http.ResponseWriter, r *http.Request) {
+	param, ok := decodeSmsParam(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.seq++
+	msgID := strconv.FormatInt(s.seq, 10)
+	code := fmt.Sprintf("%06d", s.seq%1000000)
+	if s.codeProvider != nil {
+		code = s.codeProvider(param.Mobile)
+	}
+	s.smsCodes[msgID] = &smsCode{code: code}
+	s.smsMessages = append(s.smsMessages, SMSMessage{MsgID: msgID, Mobile: param.Mobile, TempID: param.TempID, Code: code})
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]string{"msg_id": msgID})
+}
+
+// 「验证码验证」，每个验证码只能验证通过一次。
+func (s *Server) handleVerifyCode(w http.ResponseWriter, r *http.Request) {
+	var param struct {
+		Code string `json:"code"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.smsCodes[r.PathValue("msg_id")]
+	switch {
+	case !ok:
+		writeInvalidCode(w, r, codeSmsMsgIDNotFound, "code is expired")
+	case c.verified:
+		writeInvalidCode(w, r, codeSmsCodeVerified, "code is already verified")
+	case c.code != param.Code:
+		writeInvalidCode(w, r, codeSmsCodeInvalid, "invalid code")
+	default:
+		c.verified = true
+		writeJSON(w, http.StatusOK, map[string]bool{"is_valid": true})
+	}
+}
+
+func writeInvalidCode(w http.ResponseWriter, r *http.Request, code int, message string) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"is_valid": false,
+		"error":    map[string]interface{}{"code": code, "message": message},
+	})
+}
+
+// 「发送单条模板短信」
+func (s *Server) handleSendMessage(w http.ResponseWriter, r *http.Request) {
+	param, ok := decodeSmsParam(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.seq++
+	msgID := strconv.FormatInt(s.seq, 10)
+	s.smsMessages = append(s.smsMessages, SMSMessage{MsgID: msgID, Mobile: param.Mobile, TempID: param.TempID, TempParams: param.TempParams})
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]string{"msg_id": msgID})
+}
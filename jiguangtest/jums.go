@@ -0,0 +1,64 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiguangtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) routeJUms(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/sent", s.handleUmsSend)
+	mux.HandleFunc("POST /v1/broadcast", s.handleUmsSend)
+}
+
+// UMSMessage 是模拟服务中一条发送成功的 JUMS 消息的记录。
+type UMSMessage struct {
+	MsgID string          // 消息 ID
+	Path  string          // 请求路径，普通消息为 "/v1/sent"，广播消息为 "/v1/broadcast"
+	Body  json.RawMessage // 请求正文
+}
+
+// 获取所有发送成功的 JUMS 消息，按发送的顺序排列。
+func (s *Server) UMSMessages() []UMSMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]UMSMessage(nil), s.umsMessages...)
+}
+
+// 「普通消息发送」和「广播消息发送」，原样返回 `option.sendno`。
+func (s *Server) handleUmsSend(w http.ResponseWriter, r *http.Request) {
+	var param struct {
+		Option *struct {
+			SendNo string `json:"sendno"`
+		} `json:"option"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	s.mu.Lock()
+	s.seq++
+	msgID := strconv.FormatInt(s.seq, 10)
+	s.umsMessages = append(s.umsMessages, UMSMessage{MsgID: msgID, Path: r.URL.Path, Body: readBody(r)})
+	s.mu.Unlock()
+
+	result := map[string]interface{}{"code": 0, "message": "success", "msgid": msgID}
+	if param.Option != nil && param.Option.SendNo != "" {
+		result["sendno"] = param.Option.SendNo
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,386 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jiguangtest 提供了一个进程内的极光 REST API 模拟服务，用于在单元测试中替代真实的 JPush、JSMS 和 JUMS 服务。
+//
+// 模拟服务基于 httptest.Server，在内存中维护设备、标签、别名、定时任务和验证码等状态，
+// 会校验请求的 Basic 认证，记录收到的所有请求，并支持按脚本注入失败响应（如 429 或特定的错误码）：
+//
+//	srv := jiguangtest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android, Alias: "alice"})
+//	srv.Fail(jiguangtest.Failure{Path: "/v3/push", StatusCode: http.StatusTooManyRequests, Code: 2002, Times: 1})
+//
+//	client := sdk.NewClient(srv.Config())
+//	pushAPIv3, _ := client.Push()
+//	result, err := pushAPIv3.Send(ctx, param)
+//
+// 目前支持的接口：
+//   - JPush：推送、推送校验、批量单推、获取 CID、设备的标签和别名、定时任务；
+//   - JSMS：发送验证码、校验验证码、发送模板短信；
+//   - JUMS：普通消息发送和广播发送。
+package jiguangtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+// 模拟服务默认接受的凭证。
+const (
+	DefaultAppKey              = "jiguangtest-app-key"
+	DefaultMasterSecret        = "jiguangtest-master-secret"
+	DefaultDevKey              = "jiguangtest-dev-key"
+	DefaultDevSecret           = "jiguangtest-dev-secret"
+	DefaultGroupKey            = "jiguangtest-group-key"
+	DefaultGroupMasterSecret   = "jiguangtest-group-master-secret"
+	DefaultChannelKey          = "jiguangtest-channel-key"
+	DefaultChannelMasterSecret = "jiguangtest-channel-master-secret"
+)
+
+// Server 是极光 REST API 的进程内模拟服务，可被多个 goroutine 并发使用。
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	credentials map[string]string // key -> secret
+	requests    []Request
+	failures    []*Failure
+	seq         int64
+
+	devices      map[string]*Device
+	pushes       []Push
+	pushesByCID  map[string]string // CID -> msg_id
+	schedules    map[string]map[string]interface{}
+	scheduleIDs  []string
+	smsMessages  []SMSMessage
+	smsCodes     map[string]*smsCode
+	umsMessages  []UMSMessage
+	codeProvider func(mobile string) string
+}
+
+// 模拟服务的配置选项。
+type Option func(*Server)
+
+// 设置模拟服务接受的凭证，可多次设置以接受多组凭证；设置后不再接受默认凭证。
+//   - 对于分组推送，key 需要带有 "group-" 前缀，如 "group-" + groupKey。
+func WithCredentials(key, secret string) Option {
+	return func(s *Server) {
+		if s.credentials == nil {
+			s.credentials = make(map[string]string)
+		}
+		s.credentials[key] = secret
+	}
+}
+
+// 设置短信验证码的生成函数，默认为根据序号生成的 6 位数字。
+func WithCodeProvider(provider func(mobile string) string) Option {
+	return func(s *Server) {
+		s.codeProvider = provider
+	}
+}
+
+// 创建并启动模拟服务，使用完毕后需要调用 Close 关闭。
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		devices:     make(map[string]*Device),
+		pushesByCID: make(map[string]string),
+		schedules:   make(map[string]map[string]interface{}),
+		smsCodes:    make(map[string]*smsCode),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.credentials == nil {
+		s.credentials = map[string]string{
+			DefaultAppKey:              DefaultMasterSecret,
+			DefaultDevKey:              DefaultDevSecret,
+			"group-" + DefaultGroupKey: DefaultGroupMasterSecret,
+			DefaultChannelKey:          DefaultChannelMasterSecret,
+		}
+	}
+
+	mux := http.NewServeMux()
+	s.routeJPush(mux)
+	s.routeJSms(mux)
+	s.routeJUms(mux)
+	s.Server = httptest.NewServer(s.handler(mux))
+	return s
+}
+
+// 获取使用默认凭证、所有 Host 都指向模拟服务的统一客户端配置，可直接传给 sdk.NewClient。
+func (s *Server) Config() sdk.Config {
+	return sdk.Config{
+		Proto: "HTTP/1.1",
+		Hosts: sdk.Hosts{
+			Admin:  s.URL,
+			Device: s.URL,
+			Push:   s.URL,
+			Report: s.URL,
+			SMS:    s.URL,
+			UMS:    s.URL,
+		},
+		AppKey:              DefaultAppKey,
+		MasterSecret:        DefaultMasterSecret,
+		DevKey:              DefaultDevKey,
+		DevSecret:           DefaultDevSecret,
+		GroupKey:            DefaultGroupKey,
+		GroupMasterSecret:   DefaultGroupMasterSecret,
+		ChannelKey:          DefaultChannelKey,
+		ChannelMasterSecret: DefaultChannelMasterSecret,
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Request 是模拟服务收到的一个请求的记录。
+type Request struct {
+	Method   string      // 请求方法
+	Path     string      // 请求路径，如 "/v3/push"
+	RawQuery string      // 请求查询参数
+	Header   http.Header // 请求头
+	Body     []byte      // 请求正文
+	Time     time.Time   // 收到请求的时间
+}
+
+// 将请求正文解析到 v 中。
+func (r Request) Decode(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// 获取收到的所有请求（包括认证失败和注入失败的请求），按收到的顺序排列。
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// 获取收到的给定方法和路径的请求，method 为空时匹配所有方法。
+func (s *Server) RequestsTo(method, path string) []Request {
+	var result []Request
+	for _, r := range s.Requests() {
+		if (method == "" || r.Method == method) && r.Path == path {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Failure 是按脚本注入的失败响应。
+type Failure struct {
+	Method     string      // 匹配的请求方法，为空时匹配所有方法
+	Path       string      // 匹配的请求路径，以 "*" 结尾时按前缀匹配，为空时匹配所有路径
+	Times      int         // 注入的次数，不大于 0 时表示一直注入
+	StatusCode int         // 响应状态码，默认为 400
+	Code       int         // 响应正文中的错误码，为 0 时响应正文为空
+	Message    string      // 响应正文中的错误信息
+	Header     http.Header // 额外的响应头，如 "Retry-After"
+}
+
+func (f *Failure) match(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	if prefix := strings.TrimSuffix(f.Path, "*"); prefix != f.Path {
+		return strings.HasPrefix(r.URL.Path, prefix)
+	}
+	return f.Path == "" || f.Path == r.URL.Path
+}
+
+// 注入失败响应，按照注入的顺序匹配请求。
+func (s *Server) Fail(f Failure) {
+	if f.StatusCode == 0 {
+		f.StatusCode = http.StatusBadRequest
+	}
+	s.mu.Lock()
+	s.failures = append(s.failures, &f)
+	s.mu.Unlock()
+}
+
+// 注入 times 次请求频率超限的失败响应（429，错误码 2002），并携带 `Retry-After: 0` 响应头以便客户端立即重试。
+func (s *Server) FailRateLimited(method, path string, times int) {
+	s.Fail(Failure{
+		Method:     method,
+		Path:       path,
+		Times:      times,
+		StatusCode: http.StatusTooManyRequests,
+		Code:       2002,
+		Message:    "Request times is over the limit",
+		Header:     http.Header{"Retry-After": {"0"}},
+	})
+}
+
+// 获取与请求匹配的注入失败，并消耗一次注入次数。
+func (s *Server) takeFailure(r *http.Request) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.failures {
+		if !f.match(r) {
+			continue
+		}
+		if f.Times > 0 {
+			if f.Times--; f.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 记录请求、注入失败和校验 Basic 认证。
+func (s *Server) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead { // 客户端的 HTTP 协议版本探测请求，不做记录
+			return
+		}
+
+		body := readBody(r)
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method:   r.Method,
+			Path:     r.URL.Path,
+			RawQuery: r.URL.RawQuery,
+			Header:   r.Header.Clone(),
+			Body:     body,
+			Time:     time.Now(),
+		})
+		s.mu.Unlock()
+
+		if f := s.takeFailure(r); f != nil {
+			for k, v := range f.Header {
+				w.Header()[k] = v
+			}
+			if f.Code == 0 {
+				w.WriteHeader(f.StatusCode)
+				return
+			}
+			writeError(w, r, f.StatusCode, f.Code, f.Message)
+			return
+		}
+
+		if !s.authorized(r) {
+			code := 1004
+			if isJSms(r) {
+				code = 50002
+			}
+			writeError(w, r, http.StatusUnauthorized, code, "Authen failed")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	key, secret, ok := basicAuth(r)
+	if !ok {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	want, ok := s.credentials[key]
+	return ok && want == secret
+}
+
+// 解析请求的 Basic 认证。
+func basicAuth(r *http.Request) (key, secret string, ok bool) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Basic ") {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "Basic "))
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+// 生成下一个唯一 ID。
+func (s *Server) nextID() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	return s.seq
+}
+
+// 判断是否为 JSMS 接口的请求。
+func isJSms(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, "/v1/codes") || strings.HasPrefix(r.URL.Path, "/v1/messages")
+}
+
+// 判断是否为 JUMS 接口的请求。
+func isJUms(r *http.Request) bool {
+	return r.URL.Path == "/v1/sent" || r.URL.Path == "/v1/broadcast"
+}
+
+// 以 JSON 格式写入响应。
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// 按照各产品的错误格式写入错误响应：JUMS 为 `{"code": ..., "message": ...}`，其他为 `{"error": {"code": ..., "message": ...}}`。
+func writeError(w http.ResponseWriter, r *http.Request, status, code int, message string) {
+	writeErrorWith(w, r, status, code, message, nil)
+}
+
+// 写入错误响应，并在 JPush/JSMS 的 `error` 对象中附加额外的字段。
+func writeErrorWith(w http.ResponseWriter, r *http.Request, status, code int, message string, extra map[string]interface{}) {
+	if isJUms(r) {
+		writeJSON(w, status, map[string]interface{}{"code": code, "message": message})
+		return
+	}
+	e := map[string]interface{}{"code": code, "message": message}
+	for k, v := range extra {
+		e[k] = v
+	}
+	writeJSON(w, status, map[string]interface{}{"error": e})
+}
+
+// 读取请求正文，读取后请求正文仍可被再次读取。
+func readBody(r *http.Request) []byte {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body
+}
+
+// 解析 JSON 请求正文，失败时写入错误响应并返回 false。
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.Unmarshal(readBody(r), v); err != nil {
+		code := 1003
+		if isJSms(r) {
+			code = 50000
+		}
+		writeError(w, r, http.StatusBadRequest, code, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
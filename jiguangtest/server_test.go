@@ -0,0 +1,292 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiguangtest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+	"github.com/cavlabs/jiguang-sdk-go/api/jsms"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+	"github.com/cavlabs/jiguang-sdk-go/jiguangtest"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+func newPushParam(aud interface{}) *push.SendParam {
+	return &push.SendParam{
+		Platform:     platform.All,
+		Audience:     aud,
+		Notification: &push.Notification{Alert: "Hello, JPush!"},
+	}
+}
+
+func TestPush(t *testing.T) {
+	srv := jiguangtest.NewServer()
+	defer srv.Close()
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android, Alias: "alice", Tags: []string{"vip"}})
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid2", Platform: platform.IOS, Tags: []string{"vip", "beta"}})
+
+	pushAPIv3, err := sdk.NewClient(srv.Config()).Push()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	result, err := pushAPIv3.Send(ctx, newPushParam(&push.Audience{Tags: []string{"vip"}, NotTags: []string{"beta"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsSuccess() || result.MsgID == "" {
+		t.Fatalf("Send() = %+v, want success", result)
+	}
+	pushes := srv.Pushes()
+	if len(pushes) != 1 || len(pushes[0].Targets) != 1 || pushes[0].Targets[0] != "rid1" {
+		t.Fatalf("Pushes() = %+v, want one push to rid1", pushes)
+	}
+
+	result, err = pushAPIv3.Send(ctx, newPushParam(&push.Audience{Aliases: []string{"bob"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.IsSuccess() || result.Error == nil || result.Error.Code != 1011 {
+		t.Fatalf("Send() to unknown alias = %+v, want error 1011", result.Error)
+	}
+
+	if result, err = pushAPIv3.ValidateSend(ctx, newPushParam(push.BroadcastAuds)); err != nil || !result.IsSuccess() {
+		t.Fatalf("ValidateSend() = %+v, %v", result, err)
+	}
+	if n := len(srv.Pushes()); n != 1 {
+		t.Errorf("len(Pushes()) = %d after validation, want 1", n)
+	}
+}
+
+func TestPushCidDedupe(t *testing.T) {
+	srv := jiguangtest.NewServer()
+	defer srv.Close()
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android})
+
+	pushAPIv3, _ := sdk.NewClient(srv.Config()).Push()
+	ctx := context.Background()
+
+	cids, err := pushAPIv3.GetCidForPush(ctx, 1)
+	if err != nil || !cids.IsSuccess() || len(cids.CidList) != 1 {
+		t.Fatalf("GetCidForPush() = %+v, %v", cids, err)
+	}
+
+	param := newPushParam(push.BroadcastAuds)
+	param.CID = cids.CidList[0]
+	first, err := pushAPIv3.Send(ctx, param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := pushAPIv3.Send(ctx, param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.MsgID != second.MsgID {
+		t.Errorf("msg_id = %q and %q, want the same for the same cid", first.MsgID, second.MsgID)
+	}
+	if n := len(srv.Pushes()); n != 1 {
+		t.Errorf("len(Pushes()) = %d, want 1", n)
+	}
+}
+
+func TestBatchPush(t *testing.T) {
+	srv := jiguangtest.NewServer()
+	defer srv.Close()
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android})
+
+	pushAPIv3, _ := sdk.NewClient(srv.Config()).Push()
+	result, err := pushAPIv3.BatchSendByRegistrationID(context.Background(), map[string]push.BatchPushParam{
+		"cid1": {Platform: platform.All, Target: "rid1", Notification: &push.Notification{Alert: "hi"}},
+		"cid2": {Platform: platform.All, Target: "rid2", Notification: &push.Notification{Alert: "hi"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := result.SendResult["cid1"]; r.MsgID == "" {
+		t.Errorf("cid1 = %+v, want msg_id", r)
+	}
+	if r := result.SendResult["cid2"]; r.Error == nil || r.Error.Code != 1011 {
+		t.Errorf("cid2 = %+v, want error 1011", r)
+	}
+}
+
+func TestDevice(t *testing.T) {
+	srv := jiguangtest.NewServer()
+	defer srv.Close()
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android})
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid2", Platform: platform.IOS})
+
+	deviceAPIv3, _ := sdk.NewClient(srv.Config()).Device()
+	ctx := context.Background()
+
+	alias := "alice"
+	set, err := deviceAPIv3.SetDevice(ctx, "rid1", &device.DeviceSetParam{
+		Tags:  &device.TagsForDeviceSetParam{Add: []string{"vip", "beta"}},
+		Alias: &alias,
+	})
+	if err != nil || !set.IsSuccess() {
+		t.Fatalf("SetDevice() = %+v, %v", set, err)
+	}
+	got, err := deviceAPIv3.GetDevice(ctx, "rid1")
+	if err != nil || !got.IsSuccess() {
+		t.Fatalf("GetDevice() = %+v, %v", got, err)
+	}
+	if got.Alias != alias || len(got.Tags) != 2 {
+		t.Errorf("GetDevice() = %+v, want alias %q and 2 tags", got, alias)
+	}
+
+	if tag, _ := deviceAPIv3.SetTag(ctx, "vip", []string{"rid2"}, nil); !tag.IsSuccess() {
+		t.Fatalf("SetTag() = %+v", tag.Error)
+	}
+	if tag, _ := deviceAPIv3.SetTag(ctx, "vip", []string{"rid3"}, nil); tag.IsSuccess() {
+		t.Error("SetTag() with unknown device should fail")
+	}
+	if d, _ := srv.Device("rid2"); len(d.Tags) != 1 || d.Tags[0] != "vip" {
+		t.Errorf("rid2 tags = %v, want [vip]", d.Tags)
+	}
+
+	aliases, err := deviceAPIv3.GetAlias(ctx, alias)
+	if err != nil || !aliases.IsSuccess() || len(aliases.Data) != 1 || aliases.Data[0].RegistrationID != "rid1" {
+		t.Fatalf("GetAlias() = %+v, %v", aliases, err)
+	}
+	if _, err = deviceAPIv3.DeleteAlias(ctx, alias); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := srv.Device("rid1"); d.Alias != "" {
+		t.Errorf("rid1 alias = %q after DeleteAlias, want empty", d.Alias)
+	}
+
+	if got, _ = deviceAPIv3.GetDevice(ctx, "unknown"); got.IsSuccess() {
+		t.Error("GetDevice() with unknown device should fail")
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	srv := jiguangtest.NewServer()
+	defer srv.Close()
+
+	scheduleAPIv3, _ := sdk.NewClient(srv.Config()).Schedule()
+	ctx := context.Background()
+
+	created, err := scheduleAPIv3.ScheduleSend(ctx, &schedule.SendParam{
+		Name:    "daily",
+		Enabled: true,
+		Trigger: &schedule.Trigger{Single: &schedule.Single{Time: jiguang.LocalDateTimeNow()}},
+		Push:    newPushParam(push.BroadcastAuds),
+	})
+	if err != nil || !created.IsSuccess() || created.ScheduleID == "" {
+		t.Fatalf("ScheduleSend() = %+v, %v", created, err)
+	}
+
+	list, err := scheduleAPIv3.GetSchedules(ctx, 1)
+	if err != nil || list.TotalCount != 1 || len(list.Schedules) != 1 {
+		t.Fatalf("GetSchedules() = %+v, %v", list, err)
+	}
+
+	updated, err := scheduleAPIv3.UpdateSchedule(ctx, created.ScheduleID, &schedule.UpdateParam{Name: "nightly"})
+	if err != nil || !updated.IsSuccess() {
+		t.Fatalf("UpdateSchedule() = %+v, %v", updated, err)
+	}
+	got, err := scheduleAPIv3.GetSchedule(ctx, created.ScheduleID)
+	if err != nil || !got.IsSuccess() || got.Name != "nightly" {
+		t.Fatalf("GetSchedule() = %+v, %v", got, err)
+	}
+
+	if deleted, _ := scheduleAPIv3.DeleteSchedule(ctx, created.ScheduleID); !deleted.IsSuccess() {
+		t.Fatalf("DeleteSchedule() = %+v", deleted.Error)
+	}
+	if got, _ = scheduleAPIv3.GetSchedule(ctx, created.ScheduleID); got.IsSuccess() || got.StatusCode != http.StatusNotFound {
+		t.Errorf("GetSchedule() after delete = %d, want 404", got.StatusCode)
+	}
+}
+
+func TestSMS(t *testing.T) {
+	srv := jiguangtest.NewServer(jiguangtest.WithCodeProvider(func(string) string { return "123456" }))
+	defer srv.Close()
+
+	smsAPIv1, _ := sdk.NewClient(srv.Config()).SMS()
+	ctx := context.Background()
+
+	sent, err := smsAPIv1.SendCode(ctx, &jsms.CodeSendParam{Mobile: "13800138000", TempID: 1})
+	if err != nil || !sent.IsSuccess() || sent.MsgID == "" {
+		t.Fatalf("SendCode() = %+v, %v", sent, err)
+	}
+	if code := srv.SMSCode(sent.MsgID); code != "123456" {
+		t.Errorf("SMSCode() = %q, want 123456", code)
+	}
+
+	if verified, _ := smsAPIv1.VerifyCode(ctx, sent.MsgID, "000000"); verified.IsValid {
+		t.Error("VerifyCode() with wrong code should be invalid")
+	}
+	if verified, _ := smsAPIv1.VerifyCode(ctx, sent.MsgID, "123456"); !verified.IsValid {
+		t.Errorf("VerifyCode() = %+v, want valid", verified.Error)
+	}
+	if verified, _ := smsAPIv1.VerifyCode(ctx, sent.MsgID, "123456"); verified.IsValid || verified.Error.Code != 50012 {
+		t.Errorf("VerifyCode() twice = %+v, want error 50012", verified.Error)
+	}
+
+	if _, err = smsAPIv1.SendMessage(ctx, &jsms.MessageSendParam{Mobile: "13800138000", TempID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.SMSMessages()); n != 2 {
+		t.Errorf("len(SMSMessages()) = %d, want 2", n)
+	}
+}
+
+func TestAuthFailure(t *testing.T) {
+	srv := jiguangtest.NewServer(jiguangtest.WithCredentials("other", "secret"))
+	defer srv.Close()
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android})
+
+	pushAPIv3, _ := sdk.NewClient(srv.Config()).Push()
+	result, err := pushAPIv3.Send(context.Background(), newPushParam(push.BroadcastAuds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StatusCode != http.StatusUnauthorized || result.Error == nil || result.Error.Code != 1004 {
+		t.Errorf("Send() = %d %+v, want 401 with error 1004", result.StatusCode, result.Error)
+	}
+}
+
+func TestFailRateLimited(t *testing.T) {
+	srv := jiguangtest.NewServer()
+	defer srv.Close()
+	srv.AddDevice(jiguangtest.Device{RegistrationID: "rid1", Platform: platform.Android})
+	srv.FailRateLimited(http.MethodPost, "/v3/push", 2)
+
+	cfg := srv.Config()
+	cfg.RetryPolicy = &api.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	pushAPIv3, _ := sdk.NewClient(cfg).Push()
+
+	result, err := pushAPIv3.Send(context.Background(), newPushParam(push.BroadcastAuds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsSuccess() {
+		t.Fatalf("Send() = %+v, want success after retries", result.Error)
+	}
+	if n := len(srv.RequestsTo(http.MethodPost, "/v3/push")); n != 3 {
+		t.Errorf("requests = %d, want 3", n)
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sdk 提供了统一的极光 SDK 访问客户端，使用一份配置即可访问 JPush、JSMS 和 JUMS 的全部 API。
+//
+// 各 API 均在首次使用时才被创建，并共享同一个 api.HttpClient（包括其日志记录器、重试策略、频率限制器和中间件等）：
+//
+//	client := sdk.NewClient(sdk.Config{
+//		AppKey:       os.Getenv("JPUSH_APP_KEY"),
+//		MasterSecret: os.Getenv("JPUSH_MASTER_SECRET"),
+//		RetryPolicy:  api.DefaultRetryPolicy(),
+//	})
+//
+//	pushAPIv3, err := client.Push()
+//	if err != nil {
+//		panic(err)
+//	}
+//	result, err := pushAPIv3.Send(ctx, param)
+package sdk
+
+import (
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/admin"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/file"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/gpush"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/greport"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/image"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+	"github.com/cavlabs/jiguang-sdk-go/api/jsms"
+	"github.com/cavlabs/jiguang-sdk-go/api/jums"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// 在 Config 没有提供自定义 Logger 时使用 DefaultLogger。
+var DefaultLogger jiguang.Logger = jiguang.NewStdLogger(jiguang.WithLogPrefix("[Jiguang] "))
+
+// Config 是统一客户端的配置，通用设置被所有 API 共享，凭证则按产品分别设置，未用到的产品可以不设置对应凭证。
+type Config struct {
+	// 【可选】用于发送 HTTP 请求的客户端，默认为 api.DefaultClient。
+	Client api.Client
+	// 【可选】日志记录器，默认为 DefaultLogger。
+	Logger jiguang.Logger
+	// 【可选】HTTP 日志记录级别，零值为 api.HttpLogLevelNone，即不记录 HTTP 请求和响应的日志信息。
+	HttpLogLevel api.HttpLogLevel
+	// 【可选】HTTP 请求重试策略，默认不重试，详见 api.RetryPolicy 说明。
+	RetryPolicy *api.RetryPolicy
+	// 【可选】客户端频率限制器，默认不限制，详见 api.RateLimiter 说明。
+	RateLimiter *api.RateLimiter
+	// 【可选】请求/响应中间件，按照设置的顺序由外向内依次执行，详见 api.Middleware 说明。
+	Middlewares []api.Middleware
+	// 【可选】是否启用严格错误模式，详见 api.APIError 说明。
+	StrictErrors bool
+	// 【可选】是否在推送前进行本地校验（仅用于 Push），详见 push.APIv3Builder.EnableValidation 说明。
+	ValidateBeforeSend bool
+	// 【可选】应用的业务消息类别策略（仅用于 Push），默认为 options.DefaultClassPolicy，详见 push.APIv3Builder.SetClassPolicy 说明。
+	ClassPolicy push.ClassPolicy
+	// 【可选】HTTP 协议版本，如 "HTTP/1.1"、"HTTP/2.0" 等，默认为空，即在首次发送请求时自动探测。
+	Proto string
+	// 【可选】各 API 的 Host 基础 URL，未设置的使用默认值。
+	Hosts Hosts
+
+	// JPush 和 JSMS 的应用标识，用于 Push、Schedule、File、Image、Device、Report 和 SMS。
+	AppKey string
+	// JPush 和 JSMS 的应用主密钥。
+	MasterSecret string
+
+	// 开发者标识，用于 Admin，以及 GroupPush 的文件推送和 SMS 的账号余量查询（可选）。
+	DevKey string
+	// 开发者密钥。
+	DevSecret string
+
+	// 分组标识，用于 GroupPush 和 GroupReport。
+	GroupKey string
+	// 分组主密钥。
+	GroupMasterSecret string
+
+	// JUMS 的渠道标识，用于 UMS。
+	ChannelKey string
+	// JUMS 的渠道主密钥。
+	ChannelMasterSecret string
+	// JUMS 的全局访问标识，用于 UMS 的用户管理和素材管理等（可选）。
+	AccessKey string
+	// JUMS 的全局访问主密钥。
+	AccessMasterSecret string
+
+	// 【可选】AppKey 和 MasterSecret 的凭证提供者，设置后将忽略 AppKey 和 MasterSecret，以支持密钥的热轮换，详见 api.CredentialsProvider 说明。
+	AppCredentials api.CredentialsProvider
+	// 【可选】DevKey 和 DevSecret 的凭证提供者（仅用于 Admin），设置后 Admin 将忽略 DevKey 和 DevSecret。
+	DevCredentials api.CredentialsProvider
+	// 【可选】GroupKey 和 GroupMasterSecret 的凭证提供者，设置后将忽略 GroupKey 和 GroupMasterSecret。
+	GroupCredentials api.CredentialsProvider
+	// 【可选】ChannelKey 和 ChannelMasterSecret 的凭证提供者，设置后将忽略 ChannelKey 和 ChannelMasterSecret。
+	ChannelCredentials api.CredentialsProvider
+}
+
+// Hosts 是各 API 的 Host 基础 URL，为空时使用 api 包中对应的默认值。
+type Hosts struct {
+	Admin  string // 默认为 api.HostJPushAdminV1。
+	Device string // 默认为 api.HostJPushDeviceV3。
+	Push   string // 默认为 api.HostJPushPushV3，用于 Push、GroupPush、Schedule、File 和 Image。
+	Report string // 默认为 api.HostJPushReportV3，用于 Report 和 GroupReport。
+	SMS    string // 默认为 api.HostJSmsV1。
+	UMS    string // 默认为 api.HostJUmsV1。
+}
+
+func (h Hosts) withDefaults() Hosts {
+	if h.Admin == "" {
+		h.Admin = api.HostJPushAdminV1
+	}
+	if h.Device == "" {
+		h.Device = api.HostJPushDeviceV3
+	}
+	if h.Push == "" {
+		h.Push = api.HostJPushPushV3
+	}
+	if h.Report == "" {
+		h.Report = api.HostJPushReportV3
+	}
+	if h.SMS == "" {
+		h.SMS = api.HostJSmsV1
+	}
+	if h.UMS == "" {
+		h.UMS = api.HostJUmsV1
+	}
+	return h
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Client 是统一的极光 SDK 访问客户端，可被多个 goroutine 并发使用。
+//
+// 各 API 均在首次调用对应方法时才被创建，之后复用同一个实例；如果对应产品的凭证缺失，则返回创建失败的错误。
+type Client struct {
+	cfg        Config
+	httpClient api.HttpClient
+
+	admin       lazy[admin.APIv1]
+	device      lazy[device.APIv3]
+	push        lazy[push.APIv3]
+	schedule    lazy[schedule.APIv3]
+	file        lazy[file.APIv3]
+	image       lazy[image.APIv3]
+	report      lazy[report.APIv3]
+	groupPush   lazy[gpush.APIv3]
+	groupReport lazy[greport.APIv3]
+	sms         lazy[jsms.APIv1]
+	ums         lazy[jums.APIv1]
+}
+
+// 延迟创建并缓存的 API 实例。
+type lazy[T any] struct {
+	once sync.Once
+	api  T
+	err  error
+}
+
+func (l *lazy[T]) get(build func() (T, error)) (T, error) {
+	l.once.Do(func() {
+		l.api, l.err = build()
+	})
+	return l.api, l.err
+}
+
+// 使用给定的配置创建统一客户端，该方法不会发起任何网络请求。
+func NewClient(cfg Config) *Client {
+	if cfg.Logger == nil {
+		cfg.Logger = DefaultLogger
+	}
+	cfg.Hosts = cfg.Hosts.withDefaults()
+	return &Client{cfg: cfg, httpClient: cfg.newHttpClient()}
+}
+
+// 根据通用设置创建 HTTP 客户端，调用前需要确保 Logger 已设置。
+func (cfg *Config) newHttpClient() api.HttpClient {
+	return api.NewHttpClient(cfg.Client, cfg.Logger, cfg.HttpLogLevel,
+		api.WithRetryPolicy(cfg.RetryPolicy),
+		api.WithRateLimiter(cfg.RateLimiter),
+		api.WithMiddlewares(cfg.Middlewares...),
+		api.WithStrictErrors(cfg.StrictErrors))
+}
+
+// 获取所有 API 共享的 HTTP 客户端。
+func (c *Client) HttpClient() api.HttpClient {
+	return c.httpClient
+}
+
+// 获取 Admin API v1，需要设置 DevKey 和 DevSecret，或 DevCredentials。
+func (c *Client) Admin() (admin.APIv1, error) {
+	return c.admin.get(func() (admin.APIv1, error) {
+		b := admin.NewAPIv1Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Admin).
+			SetProto(c.cfg.Proto)
+		if c.cfg.DevCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.DevCredentials)
+		} else {
+			b.SetDevKey(c.cfg.DevKey).SetDevSecret(c.cfg.DevSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 Device API v3，需要设置 AppKey 和 MasterSecret，或 AppCredentials。
+func (c *Client) Device() (device.APIv3, error) {
+	return c.device.get(func() (device.APIv3, error) {
+		b := device.NewAPIv3Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Device).
+			SetProto(c.cfg.Proto)
+		if c.cfg.AppCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.AppCredentials)
+		} else {
+			b.SetAppKey(c.cfg.AppKey).SetMasterSecret(c.cfg.MasterSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 Push API v3，需要设置 AppKey 和 MasterSecret，或 AppCredentials。
+func (c *Client) Push() (push.APIv3, error) {
+	return c.push.get(func() (push.APIv3, error) {
+		b := push.NewAPIv3Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Push).
+			SetProto(c.cfg.Proto).
+			SetClassPolicy(c.cfg.ClassPolicy)
+		if c.cfg.AppCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.AppCredentials)
+		} else {
+			b.SetAppKey(c.cfg.AppKey).SetMasterSecret(c.cfg.MasterSecret)
+		}
+		if c.cfg.ValidateBeforeSend {
+			b.EnableValidation()
+		}
+		return b.Build()
+	})
+}
+
+// 获取 Schedule API v3，需要设置 AppKey 和 MasterSecret，或 AppCredentials。
+func (c *Client) Schedule() (schedule.APIv3, error) {
+	return c.schedule.get(func() (schedule.APIv3, error) {
+		b := schedule.NewAPIv3Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Push).
+			SetProto(c.cfg.Proto)
+		if c.cfg.AppCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.AppCredentials)
+		} else {
+			b.SetAppKey(c.cfg.AppKey).SetMasterSecret(c.cfg.MasterSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 File API v3，需要设置 AppKey 和 MasterSecret，或 AppCredentials。
+func (c *Client) File() (file.APIv3, error) {
+	return c.file.get(func() (file.APIv3, error) {
+		b := file.NewAPIv3Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Push).
+			SetProto(c.cfg.Proto)
+		if c.cfg.AppCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.AppCredentials)
+		} else {
+			b.SetAuthKey(c.cfg.AppKey).SetAuthSecret(c.cfg.MasterSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 Image API v3，需要设置 AppKey 和 MasterSecret，或 AppCredentials。
+func (c *Client) Image() (image.APIv3, error) {
+	return c.image.get(func() (image.APIv3, error) {
+		b := image.NewAPIv3Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Push).
+			SetProto(c.cfg.Proto)
+		if c.cfg.AppCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.AppCredentials)
+		} else {
+			b.SetAppKey(c.cfg.AppKey).SetMasterSecret(c.cfg.MasterSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 Report API v3，需要设置 AppKey 和 MasterSecret，或 AppCredentials。
+func (c *Client) Report() (report.APIv3, error) {
+	return c.report.get(func() (report.APIv3, error) {
+		b := report.NewAPIv3Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Report).
+			SetProto(c.cfg.Proto)
+		if c.cfg.AppCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.AppCredentials)
+		} else {
+			b.SetAppKey(c.cfg.AppKey).SetMasterSecret(c.cfg.MasterSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 Group Push API v3，需要设置 GroupKey 和 GroupMasterSecret，或 GroupCredentials；如需使用文件推送，还需要设置 DevKey 和 DevSecret。
+func (c *Client) GroupPush() (gpush.APIv3, error) {
+	return c.groupPush.get(func() (gpush.APIv3, error) {
+		b := gpush.NewAPIv3Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Push).
+			SetProto(c.cfg.Proto)
+		if c.cfg.GroupCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.GroupCredentials)
+		} else {
+			b.SetGroupKey(c.cfg.GroupKey).SetGroupMasterSecret(c.cfg.GroupMasterSecret)
+		}
+		if c.cfg.DevKey != "" && c.cfg.DevSecret != "" {
+			b.SetDevKey(c.cfg.DevKey).SetDevSecret(c.cfg.DevSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 Group Report API v3，需要设置 GroupKey 和 GroupMasterSecret，或 GroupCredentials。
+func (c *Client) GroupReport() (greport.APIv3, error) {
+	return c.groupReport.get(func() (greport.APIv3, error) {
+		b := greport.NewAPIv3Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.Report).
+			SetProto(c.cfg.Proto)
+		if c.cfg.GroupCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.GroupCredentials)
+		} else {
+			b.SetGroupKey(c.cfg.GroupKey).SetGroupMasterSecret(c.cfg.GroupMasterSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 JSMS API v1，需要设置 AppKey 和 MasterSecret，或 AppCredentials；如需查询账号余量，还需要设置 DevKey 和 DevSecret。
+//   - 如需启用回调接口服务，请使用 jsms.NewAPIv1Builder 并通过 SetHttpClient 共享 HttpClient。
+func (c *Client) SMS() (jsms.APIv1, error) {
+	return c.sms.get(func() (jsms.APIv1, error) {
+		b := jsms.NewAPIv1Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.SMS).
+			SetProto(c.cfg.Proto).
+			SetLogger(c.cfg.Logger)
+		if c.cfg.AppCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.AppCredentials)
+		} else {
+			b.SetAppKey(c.cfg.AppKey).SetMasterSecret(c.cfg.MasterSecret)
+		}
+		if c.cfg.DevKey != "" && c.cfg.DevSecret != "" {
+			b.SetDevKey(c.cfg.DevKey).SetDevSecret(c.cfg.DevSecret)
+		}
+		return b.Build()
+	})
+}
+
+// 获取 JUMS API v1，需要设置 ChannelKey 和 ChannelMasterSecret，或 ChannelCredentials；如需使用用户管理等全局接口，还需要设置 AccessKey 和 AccessMasterSecret。
+//   - 如需启用回调接口服务，请使用 jums.NewAPIv1Builder 并通过 SetHttpClient 共享 HttpClient。
+func (c *Client) UMS() (jums.APIv1, error) {
+	return c.ums.get(func() (jums.APIv1, error) {
+		b := jums.NewAPIv1Builder().
+			SetHttpClient(c.httpClient).
+			SetHost(c.cfg.Hosts.UMS).
+			SetProto(c.cfg.Proto).
+			SetLogger(c.cfg.Logger)
+		if c.cfg.ChannelCredentials != nil {
+			b.SetCredentialsProvider(c.cfg.ChannelCredentials)
+		} else {
+			b.SetChannelKey(c.cfg.ChannelKey).SetMasterSecret(c.cfg.ChannelMasterSecret)
+		}
+		if c.cfg.AccessKey != "" && c.cfg.AccessMasterSecret != "" {
+			b.SetAccessKey(c.cfg.AccessKey).SetAccessMasterSecret(c.cfg.AccessMasterSecret)
+		}
+		return b.Build()
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+func TestClient(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		auths = make(map[string]string)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		auths[r.URL.Path] = r.Header.Get("Authorization")
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	var requests int
+	counter := func(next api.Handler) api.Handler {
+		return func(ctx context.Context, req *api.Request) (*api.Response, error) {
+			requests++
+			return next(ctx, req)
+		}
+	}
+	client := sdk.NewClient(sdk.Config{
+		Proto:               "HTTP/1.1",
+		Middlewares:         []api.Middleware{counter},
+		Hosts:               sdk.Hosts{Device: srv.URL, Push: srv.URL, SMS: srv.URL},
+		AppKey:              "app",
+		MasterSecret:        "secret",
+		ChannelKey:          "channel",
+		ChannelMasterSecret: "channel-secret",
+	})
+
+	pushAPIv3, err := client.Push()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := client.Push(); again != pushAPIv3 {
+		t.Error("Push() should return the cached API")
+	}
+	if _, err = client.Admin(); err == nil {
+		t.Error("Admin() without dev credentials should fail")
+	}
+	if _, err = client.UMS(); err != nil {
+		t.Errorf("UMS() = %v", err)
+	}
+
+	deviceAPIv3, err := client.Device()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = deviceAPIv3.GetTags(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	smsAPIv1, err := client.SMS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = smsAPIv1.GetAppBalance(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests through shared middleware = %d, want 2", requests)
+	}
+	want := "Basic " + base64.StdEncoding.EncodeToString([]byte("app:secret"))
+	mu.Lock()
+	defer mu.Unlock()
+	for path, auth := range auths {
+		if auth != want {
+			t.Errorf("%s: Authorization = %q, want %q", path, auth, want)
+		}
+	}
+	if len(auths) != 2 {
+		t.Errorf("paths = %v", auths)
+	}
+}
+
+func TestClientCredentialsProvider(t *testing.T) {
+	var appKeys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(r.Header.Get("Authorization"), "Basic "))
+		appKey, _, _ := strings.Cut(string(auth), ":")
+		appKeys = append(appKeys, appKey)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JG_APP_KEY", "old-key")
+	t.Setenv("TEST_JG_MASTER_SECRET", "old-secret")
+	client := sdk.NewClient(sdk.Config{
+		Proto:          "HTTP/1.1",
+		Hosts:          sdk.Hosts{Device: srv.URL},
+		AppCredentials: api.EnvCredentials("TEST_JG_APP_KEY", "TEST_JG_MASTER_SECRET"),
+	})
+
+	deviceAPIv3, err := client.Device()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = deviceAPIv3.GetTags(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// 轮换密钥后，已创建的 API 实例在下一次请求时即使用新的凭证。
+	t.Setenv("TEST_JG_APP_KEY", "new-key")
+	if _, err = deviceAPIv3.GetTags(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"old-key", "new-key"}; !reflect.DeepEqual(appKeys, want) {
+		t.Errorf("appKeys = %v, want %v", appKeys, want)
+	}
+
+	if _, err = client.GroupPush(); err == nil {
+		t.Error("GroupPush() without group credentials should fail")
+	}
+	client = sdk.NewClient(sdk.Config{GroupCredentials: api.StaticCredentials("group", "secret")})
+	if _, err = client.GroupPush(); err != nil {
+		t.Errorf("GroupPush() with GroupCredentials = %v", err)
+	}
+}
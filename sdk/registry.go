@@ -0,0 +1,288 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+)
+
+// AppConfig 是注册表中单个应用的凭证配置，字段含义与 Config 中的同名凭证一致。
+type AppConfig struct {
+	Name                string `json:"name" yaml:"name"`                                                       // 【必填】应用名称，在注册表中唯一。
+	AppKey              string `json:"app_key,omitempty" yaml:"app_key,omitempty"`                             // 应用标识。
+	MasterSecret        string `json:"master_secret,omitempty" yaml:"master_secret,omitempty"`                 // 应用主密钥。
+	DevKey              string `json:"dev_key,omitempty" yaml:"dev_key,omitempty"`                             // 开发者标识。
+	DevSecret           string `json:"dev_secret,omitempty" yaml:"dev_secret,omitempty"`                       // 开发者密钥。
+	GroupKey            string `json:"group_key,omitempty" yaml:"group_key,omitempty"`                         // 分组标识。
+	GroupMasterSecret   string `json:"group_master_secret,omitempty" yaml:"group_master_secret,omitempty"`     // 分组主密钥。
+	ChannelKey          string `json:"channel_key,omitempty" yaml:"channel_key,omitempty"`                     // JUMS 渠道标识。
+	ChannelMasterSecret string `json:"channel_master_secret,omitempty" yaml:"channel_master_secret,omitempty"` // JUMS 渠道主密钥。
+	AccessKey           string `json:"access_key,omitempty" yaml:"access_key,omitempty"`                       // JUMS 全局访问标识。
+	AccessMasterSecret  string `json:"access_master_secret,omitempty" yaml:"access_master_secret,omitempty"`   // JUMS 全局访问主密钥。
+
+	// 以下凭证提供者仅能以编程方式设置，用于支持密钥的热轮换，设置后将忽略对应的静态凭证，详见 Config 中的同名字段。
+	AppCredentials     api.CredentialsProvider `json:"-" yaml:"-"`
+	DevCredentials     api.CredentialsProvider `json:"-" yaml:"-"`
+	GroupCredentials   api.CredentialsProvider `json:"-" yaml:"-"`
+	ChannelCredentials api.CredentialsProvider `json:"-" yaml:"-"`
+}
+
+// AppProvider 是应用凭证配置的来源。
+type AppProvider interface {
+	// 加载全部应用的凭证配置。
+	LoadApps() ([]AppConfig, error)
+}
+
+// AppProviderFunc 是以函数形式实现的 AppProvider，可用于以编程方式提供应用凭证配置。
+type AppProviderFunc func() ([]AppConfig, error)
+
+func (f AppProviderFunc) LoadApps() ([]AppConfig, error) {
+	return f()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 环境变量名的后缀及其对应的凭证字段，按后缀长度从长到短排列，以便优先匹配较长的后缀。
+var envAppFields = []struct {
+	suffix string
+	field  func(app *AppConfig) *string
+}{
+	{"_CHANNEL_MASTER_SECRET", func(app *AppConfig) *string { return &app.ChannelMasterSecret }},
+	{"_ACCESS_MASTER_SECRET", func(app *AppConfig) *string { return &app.AccessMasterSecret }},
+	{"_GROUP_MASTER_SECRET", func(app *AppConfig) *string { return &app.GroupMasterSecret }},
+	{"_MASTER_SECRET", func(app *AppConfig) *string { return &app.MasterSecret }},
+	{"_CHANNEL_KEY", func(app *AppConfig) *string { return &app.ChannelKey }},
+	{"_ACCESS_KEY", func(app *AppConfig) *string { return &app.AccessKey }},
+	{"_DEV_SECRET", func(app *AppConfig) *string { return &app.DevSecret }},
+	{"_GROUP_KEY", func(app *AppConfig) *string { return &app.GroupKey }},
+	{"_APP_KEY", func(app *AppConfig) *string { return &app.AppKey }},
+	{"_DEV_KEY", func(app *AppConfig) *string { return &app.DevKey }},
+}
+
+// 从环境变量中加载应用凭证配置，环境变量名的格式为 `<prefix><NAME>_<FIELD>`，应用名称为 NAME 的小写形式。
+//   - FIELD 可以是 APP_KEY、MASTER_SECRET、DEV_KEY、DEV_SECRET、GROUP_KEY、GROUP_MASTER_SECRET、
+//     CHANNEL_KEY、CHANNEL_MASTER_SECRET、ACCESS_KEY 和 ACCESS_MASTER_SECRET；
+//   - 例如 prefix 为 "JIGUANG_APP_" 时，JIGUANG_APP_SHOP_APP_KEY 和 JIGUANG_APP_SHOP_MASTER_SECRET 将被加载为名为 "shop" 的应用。
+func EnvAppProvider(prefix string) AppProvider {
+	return AppProviderFunc(func() ([]AppConfig, error) {
+		apps := make(map[string]*AppConfig)
+		for _, kv := range os.Environ() {
+			key, value, _ := strings.Cut(kv, "=")
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			key = strings.TrimPrefix(key, prefix)
+			for _, f := range envAppFields {
+				if name := strings.TrimSuffix(key, f.suffix); name != key && name != "" {
+					name = strings.ToLower(name)
+					app, ok := apps[name]
+					if !ok {
+						app = &AppConfig{Name: name}
+						apps[name] = app
+					}
+					*f.field(app) = value
+					break
+				}
+			}
+		}
+
+		result := make([]AppConfig, 0, len(apps))
+		for _, app := range apps {
+			result = append(result, *app)
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+		return result, nil
+	})
+}
+
+// 从 JSON 文件中加载应用凭证配置，文件内容为 AppConfig 的数组。
+func JSONFileAppProvider(path string) AppProvider {
+	return FileAppProvider(path, json.Unmarshal)
+}
+
+// 从文件中加载应用凭证配置，文件内容为 AppConfig 的数组，并使用给定的 unmarshal 函数解析。
+//   - 例如，对于 YAML 文件，可传入 gopkg.in/yaml.v3 的 yaml.Unmarshal，AppConfig 已带有对应的 `yaml` 标签。
+func FileAppProvider(path string, unmarshal func(data []byte, v interface{}) error) AppProvider {
+	return AppProviderFunc(func() ([]AppConfig, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var apps []AppConfig
+		if err = unmarshal(data, &apps); err != nil {
+			return nil, fmt.Errorf("parse apps file %q: %w", path, err)
+		}
+		return apps, nil
+	})
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// Registry 是多应用注册表，按应用名称提供缓存的统一客户端，可被多个 goroutine 并发使用。
+//
+// 注册表中的所有应用共享同一个 api.HttpClient 及 Config 中的通用设置（日志记录器、重试策略、频率限制器、中间件和 Host 等），
+// 频率限制器按照 Host 和 appKey 分别限流，因此多个应用之间不会相互影响。
+type Registry struct {
+	cfg        Config
+	httpClient api.HttpClient
+
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// 使用给定的通用设置创建多应用注册表，Config 中的凭证将被忽略，应用凭证通过 Add 或 Load 添加。
+func NewRegistry(cfg Config) *Registry {
+	if cfg.Logger == nil {
+		cfg.Logger = DefaultLogger
+	}
+	cfg.Hosts = cfg.Hosts.withDefaults()
+	return &Registry{
+		cfg:        cfg,
+		httpClient: cfg.newHttpClient(),
+		clients:    make(map[string]*Client),
+	}
+}
+
+// 添加应用，如果已存在同名应用，则替换之（已经获取到的旧 API 实例仍可继续使用）。
+func (r *Registry) Add(app AppConfig) error {
+	if app.Name == "" {
+		return errors.New("`name` cannot be empty")
+	}
+
+	cfg := r.cfg
+	cfg.AppKey, cfg.MasterSecret = app.AppKey, app.MasterSecret
+	cfg.DevKey, cfg.DevSecret = app.DevKey, app.DevSecret
+	cfg.GroupKey, cfg.GroupMasterSecret = app.GroupKey, app.GroupMasterSecret
+	cfg.ChannelKey, cfg.ChannelMasterSecret = app.ChannelKey, app.ChannelMasterSecret
+	cfg.AccessKey, cfg.AccessMasterSecret = app.AccessKey, app.AccessMasterSecret
+	cfg.AppCredentials, cfg.DevCredentials = app.AppCredentials, app.DevCredentials
+	cfg.GroupCredentials, cfg.ChannelCredentials = app.GroupCredentials, app.ChannelCredentials
+
+	r.mu.Lock()
+	r.clients[app.Name] = &Client{cfg: cfg, httpClient: r.httpClient}
+	r.mu.Unlock()
+	return nil
+}
+
+// 从给定的来源加载并添加应用，遇到错误时停止，已添加的应用不会被回滚。
+func (r *Registry) Load(provider AppProvider) error {
+	apps, err := provider.LoadApps()
+	if err != nil {
+		return err
+	}
+	for _, app := range apps {
+		if err = r.Add(app); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 移除应用，返回该应用是否存在。
+func (r *Registry) Remove(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.clients[name]
+	delete(r.clients, name)
+	return ok
+}
+
+// 获取所有应用名称，按名称排序。
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.clients))
+	for name := range r.clients {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// 获取应用对应的统一客户端。
+func (r *Registry) Client(name string) (*Client, error) {
+	r.mu.RLock()
+	c, ok := r.clients[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("app %q not found", name)
+	}
+	return c, nil
+}
+
+// 获取应用对应的 Push API v3。
+func (r *Registry) Push(name string) (push.APIv3, error) {
+	c, err := r.Client(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.Push()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// AppResult 是在单个应用上执行操作的结果。
+type AppResult[T any] struct {
+	App    string // 应用名称。
+	Result T      // 操作结果。
+	Err    error  // 操作错误，应用不存在时也会在此返回。
+}
+
+// 在给定的多个应用上并发执行同一个操作，并按照 names 的顺序返回每个应用的结果。
+//   - names 为空时表示注册表中的所有应用；
+//   - 单个应用的失败不会影响其他应用。
+//
+// 例如，向所有应用推送同一条广播：
+//
+//	results := sdk.FanOut(ctx, registry, nil, func(ctx context.Context, c *sdk.Client) (*push.SendResult, error) {
+//		pushAPIv3, err := c.Push()
+//		if err != nil {
+//			return nil, err
+//		}
+//		return pushAPIv3.Send(ctx, param)
+//	})
+func FanOut[T any](ctx context.Context, r *Registry, names []string, fn func(ctx context.Context, c *Client) (T, error)) []AppResult[T] {
+	if len(names) == 0 {
+		names = r.Names()
+	}
+
+	results := make([]AppResult[T], len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		results[i].App = name
+		c, err := r.Client(name)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		wg.Add(1)
+		go func(res *AppResult[T], c *Client) {
+			defer wg.Done()
+			res.Result, res.Err = fn(ctx, c)
+		}(&results[i], c)
+	}
+	wg.Wait()
+	return results
+}
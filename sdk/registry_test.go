@@ -0,0 +1,121 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/sdk"
+)
+
+func TestRegistryProviders(t *testing.T) {
+	t.Setenv("TEST_JG_SHOP_APP_KEY", "shop-key")
+	t.Setenv("TEST_JG_SHOP_MASTER_SECRET", "shop-secret")
+	t.Setenv("TEST_JG_BIG_MALL_GROUP_MASTER_SECRET", "mall-group-secret")
+
+	path := filepath.Join(t.TempDir(), "apps.json")
+	if err := os.WriteFile(path, []byte(`[{"name":"news","app_key":"news-key","master_secret":"news-secret"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := sdk.NewRegistry(sdk.Config{})
+	if err := registry.Load(sdk.EnvAppProvider("TEST_JG_")); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Load(sdk.JSONFileAppProvider(path)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := registry.Names(), []string{"big_mall", "news", "shop"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+
+	p1, err := registry.Push("shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p2, _ := registry.Push("shop"); p1 != p2 {
+		t.Error("Push() should return the cached API")
+	}
+	if _, err = registry.Push("big_mall"); err == nil {
+		t.Error("Push() without appKey should fail")
+	}
+	if err = registry.Add(sdk.AppConfig{Name: "rotating", AppCredentials: api.StaticCredentials("k", "s")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = registry.Push("rotating"); err != nil {
+		t.Errorf("Push() with AppCredentials = %v", err)
+	}
+
+	if !registry.Remove("shop") || registry.Remove("shop") {
+		t.Error("Remove() should report whether the app existed")
+	}
+	if _, err = registry.Push("shop"); err == nil {
+		t.Error("Push() of removed app should fail")
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(r.Header.Get("Authorization"), "Basic "))
+		appKey, _, _ := strings.Cut(string(auth), ":")
+		_, _ = w.Write([]byte(`{"tags":["` + appKey + `"]}`))
+	}))
+	defer srv.Close()
+
+	registry := sdk.NewRegistry(sdk.Config{Proto: "HTTP/1.1", Hosts: sdk.Hosts{Device: srv.URL}})
+	err := registry.Load(sdk.AppProviderFunc(func() ([]sdk.AppConfig, error) {
+		return []sdk.AppConfig{
+			{Name: "a", AppKey: "key-a", MasterSecret: "s"},
+			{Name: "b", AppKey: "key-b", MasterSecret: "s"},
+		}, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := sdk.FanOut(context.Background(), registry, []string{"b", "missing", "a"}, func(ctx context.Context, c *sdk.Client) (string, error) {
+		deviceAPIv3, err := c.Device()
+		if err != nil {
+			return "", err
+		}
+		result, err := deviceAPIv3.GetTags(ctx)
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(result.Tags, ","), nil
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("results = %v", results)
+	}
+	if results[0].App != "b" || results[0].Result != "key-b" || results[0].Err != nil {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+	if results[1].App != "missing" || results[1].Err == nil {
+		t.Errorf("results[1] = %+v", results[1])
+	}
+	if results[2].App != "a" || results[2].Result != "key-a" || results[2].Err != nil {
+		t.Errorf("results[2] = %+v", results[2])
+	}
+}